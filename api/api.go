@@ -0,0 +1,43 @@
+// Package api holds the OpenAPI description of the HTTP API.
+package api
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// SwaggerUI is a page that renders the document served at /openapi.json.
+//
+//go:embed swagger.html
+var SwaggerUI []byte
+
+//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var swaggerAssets embed.FS
+
+// SwaggerAssets are the Swagger UI scripts and styles the page loads, served
+// by this service so that the docs work without access to a CDN.
+func SwaggerAssets() fs.FS {
+	assets, err := fs.Sub(swaggerAssets, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
+
+// Load parses and validates the embedded OpenAPI document.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,3696 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: reviewer/v1/reviewer.proto
+
+// Reviewer assignment API for internal tooling. It is served next to the HTTP
+// API and backed by the same services; callers authenticate with the same
+// bearer credentials, sent as "authorization: Bearer <token>" metadata.
+//
+// Enumerations are carried as the strings the HTTP API uses, e.g. "OPEN" or
+// "spread_knowledge".
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TeamSettings struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ReviewersCount     int32                  `protobuf:"varint,1,opt,name=reviewers_count,json=reviewersCount,proto3" json:"reviewers_count,omitempty"`
+	EscalateToParent   bool                   `protobuf:"varint,2,opt,name=escalate_to_parent,json=escalateToParent,proto3" json:"escalate_to_parent,omitempty"`
+	LeadReviewMinLines int32                  `protobuf:"varint,3,opt,name=lead_review_min_lines,json=leadReviewMinLines,proto3" json:"lead_review_min_lines,omitempty"`
+	LeadReviewMinFiles int32                  `protobuf:"varint,4,opt,name=lead_review_min_files,json=leadReviewMinFiles,proto3" json:"lead_review_min_files,omitempty"`
+	MentorshipRequired bool                   `protobuf:"varint,5,opt,name=mentorship_required,json=mentorshipRequired,proto3" json:"mentorship_required,omitempty"`
+	ShadowJuniors      bool                   `protobuf:"varint,6,opt,name=shadow_juniors,json=shadowJuniors,proto3" json:"shadow_juniors,omitempty"`
+	// "random" or "spread_knowledge".
+	Strategy          string  `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	PairingWindowDays int32   `protobuf:"varint,8,opt,name=pairing_window_days,json=pairingWindowDays,proto3" json:"pairing_window_days,omitempty"`
+	PairingDecay      float64 `protobuf:"fixed64,9,opt,name=pairing_decay,json=pairingDecay,proto3" json:"pairing_decay,omitempty"`
+	MaxOpenReviews    int32   `protobuf:"varint,10,opt,name=max_open_reviews,json=maxOpenReviews,proto3" json:"max_open_reviews,omitempty"`
+	// "assign", "queue" or "fail".
+	OverloadPolicy string `protobuf:"bytes,11,opt,name=overload_policy,json=overloadPolicy,proto3" json:"overload_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TeamSettings) Reset() {
+	*x = TeamSettings{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSettings) ProtoMessage() {}
+
+func (x *TeamSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSettings.ProtoReflect.Descriptor instead.
+func (*TeamSettings) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TeamSettings) GetReviewersCount() int32 {
+	if x != nil {
+		return x.ReviewersCount
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetEscalateToParent() bool {
+	if x != nil {
+		return x.EscalateToParent
+	}
+	return false
+}
+
+func (x *TeamSettings) GetLeadReviewMinLines() int32 {
+	if x != nil {
+		return x.LeadReviewMinLines
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetLeadReviewMinFiles() int32 {
+	if x != nil {
+		return x.LeadReviewMinFiles
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetMentorshipRequired() bool {
+	if x != nil {
+		return x.MentorshipRequired
+	}
+	return false
+}
+
+func (x *TeamSettings) GetShadowJuniors() bool {
+	if x != nil {
+		return x.ShadowJuniors
+	}
+	return false
+}
+
+func (x *TeamSettings) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *TeamSettings) GetPairingWindowDays() int32 {
+	if x != nil {
+		return x.PairingWindowDays
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetPairingDecay() float64 {
+	if x != nil {
+		return x.PairingDecay
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetMaxOpenReviews() int32 {
+	if x != nil {
+		return x.MaxOpenReviews
+	}
+	return 0
+}
+
+func (x *TeamSettings) GetOverloadPolicy() string {
+	if x != nil {
+		return x.OverloadPolicy
+	}
+	return ""
+}
+
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Primary team.
+	TeamName string `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// "junior", "middle" or "senior".
+	Seniority      string `protobuf:"bytes,5,opt,name=seniority,proto3" json:"seniority,omitempty"`
+	MaxOpenReviews *int32 `protobuf:"varint,6,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetSeniority() string {
+	if x != nil {
+		return x.Seniority
+	}
+	return ""
+}
+
+func (x *User) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+type TeamMember struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	User         *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	IsPrimary    bool                   `protobuf:"varint,2,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	ReviewWeight float64                `protobuf:"fixed64,3,opt,name=review_weight,json=reviewWeight,proto3" json:"review_weight,omitempty"`
+	// "member" or "lead".
+	Role          string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	OpenReviews   int32  `protobuf:"varint,5,opt,name=open_reviews,json=openReviews,proto3" json:"open_reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TeamMember) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *TeamMember) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+func (x *TeamMember) GetReviewWeight() float64 {
+	if x != nil {
+		return x.ReviewWeight
+	}
+	return 0
+}
+
+func (x *TeamMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *TeamMember) GetOpenReviews() int32 {
+	if x != nil {
+		return x.OpenReviews
+	}
+	return 0
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ParentTeam    string                 `protobuf:"bytes,2,opt,name=parent_team,json=parentTeam,proto3" json:"parent_team,omitempty"`
+	Settings      *TeamSettings          `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetParentTeam() string {
+	if x != nil {
+		return x.ParentTeam
+	}
+	return ""
+}
+
+func (x *Team) GetSettings() *TeamSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type NewTeamMember struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Empty keeps the seniority already stored for the user.
+	Seniority string `protobuf:"bytes,4,opt,name=seniority,proto3" json:"seniority,omitempty"`
+	// Defaults to "member".
+	Role string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	// Scales how often the member is picked; defaults to 1.
+	ReviewWeight *float64 `protobuf:"fixed64,6,opt,name=review_weight,json=reviewWeight,proto3,oneof" json:"review_weight,omitempty"`
+	// Only used by UpdateTeam; members of a new team are always primary.
+	IsPrimary     bool `protobuf:"varint,7,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewTeamMember) Reset() {
+	*x = NewTeamMember{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewTeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewTeamMember) ProtoMessage() {}
+
+func (x *NewTeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewTeamMember.ProtoReflect.Descriptor instead.
+func (*NewTeamMember) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NewTeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NewTeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *NewTeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *NewTeamMember) GetSeniority() string {
+	if x != nil {
+		return x.Seniority
+	}
+	return ""
+}
+
+func (x *NewTeamMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *NewTeamMember) GetReviewWeight() float64 {
+	if x != nil && x.ReviewWeight != nil {
+		return *x.ReviewWeight
+	}
+	return 0
+}
+
+func (x *NewTeamMember) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+type AddTeamRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TeamName   string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ParentTeam string                 `protobuf:"bytes,2,opt,name=parent_team,json=parentTeam,proto3" json:"parent_team,omitempty"`
+	Members    []*NewTeamMember       `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	// Move members that already belong to another team instead of failing.
+	MoveExistingMembers bool `protobuf:"varint,4,opt,name=move_existing_members,json=moveExistingMembers,proto3" json:"move_existing_members,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AddTeamRequest) GetParentTeam() string {
+	if x != nil {
+		return x.ParentTeam
+	}
+	return ""
+}
+
+func (x *AddTeamRequest) GetMembers() []*NewTeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *AddTeamRequest) GetMoveExistingMembers() bool {
+	if x != nil {
+		return x.MoveExistingMembers
+	}
+	return false
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type UpdateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	AddMembers    []*NewTeamMember       `protobuf:"bytes,2,rep,name=add_members,json=addMembers,proto3" json:"add_members,omitempty"`
+	RemoveMembers []string               `protobuf:"bytes,3,rep,name=remove_members,json=removeMembers,proto3" json:"remove_members,omitempty"`
+	// Replaces all settings at once. Empty strategy, window, decay and policy
+	// take their defaults.
+	Settings *TeamSettings `protobuf:"bytes,4,opt,name=settings,proto3,oneof" json:"settings,omitempty"`
+	// An empty string detaches the team from its parent. A team admin must
+	// administer the new parent as well.
+	ParentTeam *string `protobuf:"bytes,5,opt,name=parent_team,json=parentTeam,proto3,oneof" json:"parent_team,omitempty"`
+	// Move the primary membership of added primary members that belong to
+	// another team instead of failing.
+	MoveExistingMembers bool `protobuf:"varint,6,opt,name=move_existing_members,json=moveExistingMembers,proto3" json:"move_existing_members,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateTeamRequest) Reset() {
+	*x = UpdateTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamRequest) ProtoMessage() {}
+
+func (x *UpdateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetAddMembers() []*NewTeamMember {
+	if x != nil {
+		return x.AddMembers
+	}
+	return nil
+}
+
+func (x *UpdateTeamRequest) GetRemoveMembers() []string {
+	if x != nil {
+		return x.RemoveMembers
+	}
+	return nil
+}
+
+func (x *UpdateTeamRequest) GetSettings() *TeamSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *UpdateTeamRequest) GetParentTeam() string {
+	if x != nil && x.ParentTeam != nil {
+		return *x.ParentTeam
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetMoveExistingMembers() bool {
+	if x != nil {
+		return x.MoveExistingMembers
+	}
+	return false
+}
+
+type RenameTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTeamRequest) Reset() {
+	*x = RenameTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTeamRequest) ProtoMessage() {}
+
+func (x *RenameTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTeamRequest.ProtoReflect.Descriptor instead.
+func (*RenameTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RenameTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RenameTeamRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type DeleteTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	TargetTeam    string                 `protobuf:"bytes,2,opt,name=target_team,json=targetTeam,proto3" json:"target_team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamRequest) Reset() {
+	*x = DeleteTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamRequest) ProtoMessage() {}
+
+func (x *DeleteTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *DeleteTeamRequest) GetTargetTeam() string {
+	if x != nil {
+		return x.TargetTeam
+	}
+	return ""
+}
+
+type DeleteTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamResponse) Reset() {
+	*x = DeleteTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamResponse) ProtoMessage() {}
+
+func (x *DeleteTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{10}
+}
+
+type TeamNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Children      []*TeamNode            `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamNode) Reset() {
+	*x = TeamNode{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamNode) ProtoMessage() {}
+
+func (x *TeamNode) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamNode.ProtoReflect.Descriptor instead.
+func (*TeamNode) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TeamNode) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamNode) GetChildren() []*TeamNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type GetTeamTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamTreeRequest) Reset() {
+	*x = GetTeamTreeRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamTreeRequest) ProtoMessage() {}
+
+func (x *GetTeamTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamTreeRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTeamTreeRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetTeamTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*TeamNode            `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamTreeResponse) Reset() {
+	*x = GetTeamTreeResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamTreeResponse) ProtoMessage() {}
+
+func (x *GetTeamTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamTreeResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTeamTreeResponse) GetTeams() []*TeamNode {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type GetTeamStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamStatsRequest) Reset() {
+	*x = GetTeamStatsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamStatsRequest) ProtoMessage() {}
+
+func (x *GetTeamStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamStatsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTeamStatsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type TeamStatsCounters struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Members           int32                  `protobuf:"varint,1,opt,name=members,proto3" json:"members,omitempty"`
+	ActiveMembers     int32                  `protobuf:"varint,2,opt,name=active_members,json=activeMembers,proto3" json:"active_members,omitempty"`
+	OpenPrs           int32                  `protobuf:"varint,3,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	MergedPrs         int32                  `protobuf:"varint,4,opt,name=merged_prs,json=mergedPrs,proto3" json:"merged_prs,omitempty"`
+	ClosedPrs         int32                  `protobuf:"varint,5,opt,name=closed_prs,json=closedPrs,proto3" json:"closed_prs,omitempty"`
+	ReviewAssignments int32                  `protobuf:"varint,6,opt,name=review_assignments,json=reviewAssignments,proto3" json:"review_assignments,omitempty"`
+	// Reviews the members declined.
+	Declines      int32 `protobuf:"varint,7,opt,name=declines,proto3" json:"declines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamStatsCounters) Reset() {
+	*x = TeamStatsCounters{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamStatsCounters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamStatsCounters) ProtoMessage() {}
+
+func (x *TeamStatsCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamStatsCounters.ProtoReflect.Descriptor instead.
+func (*TeamStatsCounters) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TeamStatsCounters) GetMembers() int32 {
+	if x != nil {
+		return x.Members
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetActiveMembers() int32 {
+	if x != nil {
+		return x.ActiveMembers
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetOpenPrs() int32 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetMergedPrs() int32 {
+	if x != nil {
+		return x.MergedPrs
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetClosedPrs() int32 {
+	if x != nil {
+		return x.ClosedPrs
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetReviewAssignments() int32 {
+	if x != nil {
+		return x.ReviewAssignments
+	}
+	return 0
+}
+
+func (x *TeamStatsCounters) GetDeclines() int32 {
+	if x != nil {
+		return x.Declines
+	}
+	return 0
+}
+
+type ReviewPairing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPairing) Reset() {
+	*x = ReviewPairing{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPairing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPairing) ProtoMessage() {}
+
+func (x *ReviewPairing) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPairing.ProtoReflect.Descriptor instead.
+func (*ReviewPairing) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReviewPairing) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReviewPairing) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewPairing) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TeamStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TeamName          string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Subteams          []string               `protobuf:"bytes,2,rep,name=subteams,proto3" json:"subteams,omitempty"`
+	Own               *TeamStatsCounters     `protobuf:"bytes,3,opt,name=own,proto3" json:"own,omitempty"`
+	Rollup            *TeamStatsCounters     `protobuf:"bytes,4,opt,name=rollup,proto3" json:"rollup,omitempty"`
+	PairingWindowDays int32                  `protobuf:"varint,5,opt,name=pairing_window_days,json=pairingWindowDays,proto3" json:"pairing_window_days,omitempty"`
+	Pairings          []*ReviewPairing       `protobuf:"bytes,6,rep,name=pairings,proto3" json:"pairings,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TeamStats) Reset() {
+	*x = TeamStats{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamStats) ProtoMessage() {}
+
+func (x *TeamStats) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamStats.ProtoReflect.Descriptor instead.
+func (*TeamStats) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TeamStats) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamStats) GetSubteams() []string {
+	if x != nil {
+		return x.Subteams
+	}
+	return nil
+}
+
+func (x *TeamStats) GetOwn() *TeamStatsCounters {
+	if x != nil {
+		return x.Own
+	}
+	return nil
+}
+
+func (x *TeamStats) GetRollup() *TeamStatsCounters {
+	if x != nil {
+		return x.Rollup
+	}
+	return nil
+}
+
+func (x *TeamStats) GetPairingWindowDays() int32 {
+	if x != nil {
+		return x.PairingWindowDays
+	}
+	return 0
+}
+
+func (x *TeamStats) GetPairings() []*ReviewPairing {
+	if x != nil {
+		return x.Pairings
+	}
+	return nil
+}
+
+type SetIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetSeniorityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Seniority     string                 `protobuf:"bytes,2,opt,name=seniority,proto3" json:"seniority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSeniorityRequest) Reset() {
+	*x = SetSeniorityRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSeniorityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSeniorityRequest) ProtoMessage() {}
+
+func (x *SetSeniorityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSeniorityRequest.ProtoReflect.Descriptor instead.
+func (*SetSeniorityRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetSeniorityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetSeniorityRequest) GetSeniority() string {
+	if x != nil {
+		return x.Seniority
+	}
+	return ""
+}
+
+type SetMaxOpenReviewsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MaxOpenReviews *int32                 `protobuf:"varint,2,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetMaxOpenReviewsRequest) Reset() {
+	*x = SetMaxOpenReviewsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaxOpenReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaxOpenReviewsRequest) ProtoMessage() {}
+
+func (x *SetMaxOpenReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaxOpenReviewsRequest.ProtoReflect.Descriptor instead.
+func (*SetMaxOpenReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetMaxOpenReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetMaxOpenReviewsRequest) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+type ListReviewsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Leave out pull requests the reviewer has already approved.
+	OnlyPending   bool   `protobuf:"varint,3,opt,name=only_pending,json=onlyPending,proto3" json:"only_pending,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetOnlyPending() bool {
+	if x != nil {
+		return x.OnlyPending
+	}
+	return false
+}
+
+func (x *ListReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ReviewCounts struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Total    int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Open     int32                  `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+	Merged   int32                  `protobuf:"varint,3,opt,name=merged,proto3" json:"merged,omitempty"`
+	Closed   int32                  `protobuf:"varint,4,opt,name=closed,proto3" json:"closed,omitempty"`
+	Approved int32                  `protobuf:"varint,5,opt,name=approved,proto3" json:"approved,omitempty"`
+	// PRs the user declined to review and was replaced on.
+	Declined      int32 `protobuf:"varint,6,opt,name=declined,proto3" json:"declined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewCounts) Reset() {
+	*x = ReviewCounts{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewCounts) ProtoMessage() {}
+
+func (x *ReviewCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewCounts.ProtoReflect.Descriptor instead.
+func (*ReviewCounts) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReviewCounts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetOpen() int32 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetMerged() int32 {
+	if x != nil {
+		return x.Merged
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetClosed() int32 {
+	if x != nil {
+		return x.Closed
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetApproved() int32 {
+	if x != nil {
+		return x.Approved
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetDeclined() int32 {
+	if x != nil {
+		return x.Declined
+	}
+	return 0
+}
+
+type ListReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Counts        *ReviewCounts          `protobuf:"bytes,4,opt,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListReviewsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReviewsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListReviewsResponse) GetCounts() *ReviewCounts {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type PullRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// "OPEN", "MERGED" or "CLOSED".
+	Status       string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	LinesChanged int32                  `protobuf:"varint,7,opt,name=lines_changed,json=linesChanged,proto3" json:"lines_changed,omitempty"`
+	FilesChanged int32                  `protobuf:"varint,8,opt,name=files_changed,json=filesChanged,proto3" json:"files_changed,omitempty"`
+	// Reviewers were assigned beyond their capacity.
+	Overloaded bool `protobuf:"varint,9,opt,name=overloaded,proto3" json:"overloaded,omitempty"`
+	// Reviewer slots wait for capacity to free up.
+	Queued          bool     `protobuf:"varint,10,opt,name=queued,proto3" json:"queued,omitempty"`
+	Reviewers       []string `protobuf:"bytes,11,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	LeadReviewers   []string `protobuf:"bytes,12,rep,name=lead_reviewers,json=leadReviewers,proto3" json:"lead_reviewers,omitempty"`
+	ShadowReviewers []string `protobuf:"bytes,13,rep,name=shadow_reviewers,json=shadowReviewers,proto3" json:"shadow_reviewers,omitempty"`
+	Approvals       []string `protobuf:"bytes,14,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	// Grows with every change of the pull request or of its reviewers.
+	Version       int64 `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetLinesChanged() int32 {
+	if x != nil {
+		return x.LinesChanged
+	}
+	return 0
+}
+
+func (x *PullRequest) GetFilesChanged() int32 {
+	if x != nil {
+		return x.FilesChanged
+	}
+	return 0
+}
+
+func (x *PullRequest) GetOverloaded() bool {
+	if x != nil {
+		return x.Overloaded
+	}
+	return false
+}
+
+func (x *PullRequest) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+func (x *PullRequest) GetReviewers() []string {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetLeadReviewers() []string {
+	if x != nil {
+		return x.LeadReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetShadowReviewers() []string {
+	if x != nil {
+		return x.ShadowReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetApprovals() []string {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+func (x *PullRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	LinesChanged    int32                  `protobuf:"varint,4,opt,name=lines_changed,json=linesChanged,proto3" json:"lines_changed,omitempty"`
+	FilesChanged    int32                  `protobuf:"varint,5,opt,name=files_changed,json=filesChanged,proto3" json:"files_changed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetLinesChanged() int32 {
+	if x != nil {
+		return x.LinesChanged
+	}
+	return 0
+}
+
+func (x *CreatePullRequestRequest) GetFilesChanged() int32 {
+	if x != nil {
+		return x.FilesChanged
+	}
+	return 0
+}
+
+type PreviewAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// Only counted as changed files when files_changed is not given.
+	Paths         []string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+	LinesChanged  int32    `protobuf:"varint,4,opt,name=lines_changed,json=linesChanged,proto3" json:"lines_changed,omitempty"`
+	FilesChanged  int32    `protobuf:"varint,5,opt,name=files_changed,json=filesChanged,proto3" json:"files_changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewAssignmentRequest) Reset() {
+	*x = PreviewAssignmentRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAssignmentRequest) ProtoMessage() {}
+
+func (x *PreviewAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*PreviewAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PreviewAssignmentRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PreviewAssignmentRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PreviewAssignmentRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *PreviewAssignmentRequest) GetLinesChanged() int32 {
+	if x != nil {
+		return x.LinesChanged
+	}
+	return 0
+}
+
+func (x *PreviewAssignmentRequest) GetFilesChanged() int32 {
+	if x != nil {
+		return x.FilesChanged
+	}
+	return 0
+}
+
+type ReviewerAssignment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ReviewerId string                 `protobuf:"bytes,1,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// "regular", "lead" or "shadow".
+	Slot          string `protobuf:"bytes,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerAssignment) Reset() {
+	*x = ReviewerAssignment{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerAssignment) ProtoMessage() {}
+
+func (x *ReviewerAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerAssignment.ProtoReflect.Descriptor instead.
+func (*ReviewerAssignment) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReviewerAssignment) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewerAssignment) GetSlot() string {
+	if x != nil {
+		return x.Slot
+	}
+	return ""
+}
+
+type CandidateScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Factor        float64                `protobuf:"fixed64,4,opt,name=factor,proto3" json:"factor,omitempty"`
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	Probability   float64                `protobuf:"fixed64,6,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandidateScore) Reset() {
+	*x = CandidateScore{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandidateScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateScore) ProtoMessage() {}
+
+func (x *CandidateScore) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateScore.ProtoReflect.Descriptor instead.
+func (*CandidateScore) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CandidateScore) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CandidateScore) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CandidateScore) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *CandidateScore) GetFactor() float64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *CandidateScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CandidateScore) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type ExcludedCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExcludedCandidate) Reset() {
+	*x = ExcludedCandidate{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExcludedCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExcludedCandidate) ProtoMessage() {}
+
+func (x *ExcludedCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExcludedCandidate.ProtoReflect.Descriptor instead.
+func (*ExcludedCandidate) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExcludedCandidate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExcludedCandidate) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ExcludedCandidate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AssignmentDecision struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DecisionId         int64                  `protobuf:"varint,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	PullRequestId      string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId         string                 `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Slot               string                 `protobuf:"bytes,4,opt,name=slot,proto3" json:"slot,omitempty"`
+	Strategy           string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	ReplacedReviewerId string                 `protobuf:"bytes,6,opt,name=replaced_reviewer_id,json=replacedReviewerId,proto3" json:"replaced_reviewer_id,omitempty"`
+	OverCapacity       bool                   `protobuf:"varint,7,opt,name=over_capacity,json=overCapacity,proto3" json:"over_capacity,omitempty"`
+	Candidates         []*CandidateScore      `protobuf:"bytes,8,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Excluded           []*ExcludedCandidate   `protobuf:"bytes,9,rep,name=excluded,proto3" json:"excluded,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssignmentDecision) Reset() {
+	*x = AssignmentDecision{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentDecision) ProtoMessage() {}
+
+func (x *AssignmentDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentDecision.ProtoReflect.Descriptor instead.
+func (*AssignmentDecision) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AssignmentDecision) GetDecisionId() int64 {
+	if x != nil {
+		return x.DecisionId
+	}
+	return 0
+}
+
+func (x *AssignmentDecision) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *AssignmentDecision) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *AssignmentDecision) GetSlot() string {
+	if x != nil {
+		return x.Slot
+	}
+	return ""
+}
+
+func (x *AssignmentDecision) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *AssignmentDecision) GetReplacedReviewerId() string {
+	if x != nil {
+		return x.ReplacedReviewerId
+	}
+	return ""
+}
+
+func (x *AssignmentDecision) GetOverCapacity() bool {
+	if x != nil {
+		return x.OverCapacity
+	}
+	return false
+}
+
+func (x *AssignmentDecision) GetCandidates() []*CandidateScore {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *AssignmentDecision) GetExcluded() []*ExcludedCandidate {
+	if x != nil {
+		return x.Excluded
+	}
+	return nil
+}
+
+func (x *AssignmentDecision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AssignmentPreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Strategy      string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Reviewers     []*ReviewerAssignment  `protobuf:"bytes,4,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	Queued        bool                   `protobuf:"varint,5,opt,name=queued,proto3" json:"queued,omitempty"`
+	Overloaded    bool                   `protobuf:"varint,6,opt,name=overloaded,proto3" json:"overloaded,omitempty"`
+	Ranking       []*CandidateScore      `protobuf:"bytes,7,rep,name=ranking,proto3" json:"ranking,omitempty"`
+	Decisions     []*AssignmentDecision  `protobuf:"bytes,8,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentPreview) Reset() {
+	*x = AssignmentPreview{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentPreview) ProtoMessage() {}
+
+func (x *AssignmentPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentPreview.ProtoReflect.Descriptor instead.
+func (*AssignmentPreview) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssignmentPreview) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *AssignmentPreview) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AssignmentPreview) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *AssignmentPreview) GetReviewers() []*ReviewerAssignment {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+func (x *AssignmentPreview) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+func (x *AssignmentPreview) GetOverloaded() bool {
+	if x != nil {
+		return x.Overloaded
+	}
+	return false
+}
+
+func (x *AssignmentPreview) GetRanking() []*CandidateScore {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+func (x *AssignmentPreview) GetDecisions() []*AssignmentDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type MergeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Fails with ABORTED unless the pull request is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeRequest) Reset() {
+	*x = MergeRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRequest) ProtoMessage() {}
+
+func (x *MergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRequest.ProtoReflect.Descriptor instead.
+func (*MergeRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MergeRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergeRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId     string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	// Fails with ABORTED unless the pull request is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReassignRequest) Reset() {
+	*x = ReassignRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignRequest) ProtoMessage() {}
+
+func (x *ReassignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignRequest.ProtoReflect.Descriptor instead.
+func (*ReassignRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReassignRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+func (x *ReassignRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignResponse) Reset() {
+	*x = ReassignResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignResponse) ProtoMessage() {}
+
+func (x *ReassignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignResponse.ProtoReflect.Descriptor instead.
+func (*ReassignResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReassignResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type DeclineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Fails with ABORTED unless the pull request is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeclineRequest) Reset() {
+	*x = DeclineRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineRequest) ProtoMessage() {}
+
+func (x *DeclineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineRequest.ProtoReflect.Descriptor instead.
+func (*DeclineRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeclineRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *DeclineRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeclineRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ApproveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Fails with ABORTED unless the pull request is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ApproveRequest) Reset() {
+	*x = ApproveRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveRequest) ProtoMessage() {}
+
+func (x *ApproveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveRequest.ProtoReflect.Descriptor instead.
+func (*ApproveRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ApproveRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ApproveRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type CloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Fails with ABORTED unless the pull request is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CloseRequest) Reset() {
+	*x = CloseRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRequest) ProtoMessage() {}
+
+func (x *CloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
+func (*CloseRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CloseRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CloseRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetHistoryRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type PREvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// "created", "reviewer_assigned", "reviewer_replaced", "reviewed",
+	// "merged" or "closed".
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	OldReviewerId string                 `protobuf:"bytes,4,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	NewReviewerId string                 `protobuf:"bytes,5,opt,name=new_reviewer_id,json=newReviewerId,proto3" json:"new_reviewer_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,6,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PREvent) Reset() {
+	*x = PREvent{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PREvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PREvent) ProtoMessage() {}
+
+func (x *PREvent) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PREvent.ProtoReflect.Descriptor instead.
+func (*PREvent) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PREvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *PREvent) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PREvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PREvent) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+func (x *PREvent) GetNewReviewerId() string {
+	if x != nil {
+		return x.NewReviewerId
+	}
+	return ""
+}
+
+func (x *PREvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PREvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PREvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Events        []*PREvent             `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetHistoryResponse) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *GetHistoryResponse) GetEvents() []*PREvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ExplainAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Only explain the picks of this reviewer.
+	ReviewerId    string `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainAssignmentRequest) Reset() {
+	*x = ExplainAssignmentRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAssignmentRequest) ProtoMessage() {}
+
+func (x *ExplainAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*ExplainAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExplainAssignmentRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ExplainAssignmentRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+type ExplainAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Decisions     []*AssignmentDecision  `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainAssignmentResponse) Reset() {
+	*x = ExplainAssignmentResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAssignmentResponse) ProtoMessage() {}
+
+func (x *ExplainAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*ExplainAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExplainAssignmentResponse) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ExplainAssignmentResponse) GetDecisions() []*AssignmentDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type SearchPullRequestsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId   string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Team of the author.
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MergedFrom    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	MergedTo      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_to,json=mergedTo,proto3" json:"merged_to,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsRequest) Reset() {
+	*x = SearchPullRequestsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsRequest) ProtoMessage() {}
+
+func (x *SearchPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SearchPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsRequest) GetMergedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedFrom
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsRequest) GetMergedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedTo
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPullRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SearchPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsResponse) Reset() {
+	*x = SearchPullRequestsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsResponse) ProtoMessage() {}
+
+func (x *SearchPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SearchPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_reviewer_v1_reviewer_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_reviewer_proto_rawDesc = "" +
+	"\n" +
+	"\x1areviewer/v1/reviewer.proto\x12\vreviewer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x03\n" +
+	"\fTeamSettings\x12'\n" +
+	"\x0freviewers_count\x18\x01 \x01(\x05R\x0ereviewersCount\x12,\n" +
+	"\x12escalate_to_parent\x18\x02 \x01(\bR\x10escalateToParent\x121\n" +
+	"\x15lead_review_min_lines\x18\x03 \x01(\x05R\x12leadReviewMinLines\x121\n" +
+	"\x15lead_review_min_files\x18\x04 \x01(\x05R\x12leadReviewMinFiles\x12/\n" +
+	"\x13mentorship_required\x18\x05 \x01(\bR\x12mentorshipRequired\x12%\n" +
+	"\x0eshadow_juniors\x18\x06 \x01(\bR\rshadowJuniors\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12.\n" +
+	"\x13pairing_window_days\x18\b \x01(\x05R\x11pairingWindowDays\x12#\n" +
+	"\rpairing_decay\x18\t \x01(\x01R\fpairingDecay\x12(\n" +
+	"\x10max_open_reviews\x18\n" +
+	" \x01(\x05R\x0emaxOpenReviews\x12'\n" +
+	"\x0foverload_policy\x18\v \x01(\tR\x0eoverloadPolicy\"\xd7\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12\x1c\n" +
+	"\tseniority\x18\x05 \x01(\tR\tseniority\x12-\n" +
+	"\x10max_open_reviews\x18\x06 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01B\x13\n" +
+	"\x11_max_open_reviews\"\xae\x01\n" +
+	"\n" +
+	"TeamMember\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.reviewer.v1.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x02 \x01(\bR\tisPrimary\x12#\n" +
+	"\rreview_weight\x18\x03 \x01(\x01R\freviewWeight\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12!\n" +
+	"\fopen_reviews\x18\x05 \x01(\x05R\vopenReviews\"\xae\x01\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vparent_team\x18\x02 \x01(\tR\n" +
+	"parentTeam\x125\n" +
+	"\bsettings\x18\x03 \x01(\v2\x19.reviewer.v1.TeamSettingsR\bsettings\x121\n" +
+	"\amembers\x18\x04 \x03(\v2\x17.reviewer.v1.TeamMemberR\amembers\"\xee\x01\n" +
+	"\rNewTeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12\x1c\n" +
+	"\tseniority\x18\x04 \x01(\tR\tseniority\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12(\n" +
+	"\rreview_weight\x18\x06 \x01(\x01H\x00R\freviewWeight\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\a \x01(\bR\tisPrimaryB\x10\n" +
+	"\x0e_review_weight\"\xb8\x01\n" +
+	"\x0eAddTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vparent_team\x18\x02 \x01(\tR\n" +
+	"parentTeam\x124\n" +
+	"\amembers\x18\x03 \x03(\v2\x1a.reviewer.v1.NewTeamMemberR\amembers\x122\n" +
+	"\x15move_existing_members\x18\x04 \x01(\bR\x13moveExistingMembers\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\xc7\x02\n" +
+	"\x11UpdateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12;\n" +
+	"\vadd_members\x18\x02 \x03(\v2\x1a.reviewer.v1.NewTeamMemberR\n" +
+	"addMembers\x12%\n" +
+	"\x0eremove_members\x18\x03 \x03(\tR\rremoveMembers\x12:\n" +
+	"\bsettings\x18\x04 \x01(\v2\x19.reviewer.v1.TeamSettingsH\x00R\bsettings\x88\x01\x01\x12$\n" +
+	"\vparent_team\x18\x05 \x01(\tH\x01R\n" +
+	"parentTeam\x88\x01\x01\x122\n" +
+	"\x15move_existing_members\x18\x06 \x01(\bR\x13moveExistingMembersB\v\n" +
+	"\t_settingsB\x0e\n" +
+	"\f_parent_team\"K\n" +
+	"\x11RenameTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"Q\n" +
+	"\x11DeleteTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vtarget_team\x18\x02 \x01(\tR\n" +
+	"targetTeam\"\x14\n" +
+	"\x12DeleteTeamResponse\"Z\n" +
+	"\bTeamNode\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\bchildren\x18\x02 \x03(\v2\x15.reviewer.v1.TeamNodeR\bchildren\"1\n" +
+	"\x12GetTeamTreeRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"B\n" +
+	"\x13GetTeamTreeResponse\x12+\n" +
+	"\x05teams\x18\x01 \x03(\v2\x15.reviewer.v1.TeamNodeR\x05teams\"2\n" +
+	"\x13GetTeamStatsRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\xf8\x01\n" +
+	"\x11TeamStatsCounters\x12\x18\n" +
+	"\amembers\x18\x01 \x01(\x05R\amembers\x12%\n" +
+	"\x0eactive_members\x18\x02 \x01(\x05R\ractiveMembers\x12\x19\n" +
+	"\bopen_prs\x18\x03 \x01(\x05R\aopenPrs\x12\x1d\n" +
+	"\n" +
+	"merged_prs\x18\x04 \x01(\x05R\tmergedPrs\x12\x1d\n" +
+	"\n" +
+	"closed_prs\x18\x05 \x01(\x05R\tclosedPrs\x12-\n" +
+	"\x12review_assignments\x18\x06 \x01(\x05R\x11reviewAssignments\x12\x1a\n" +
+	"\bdeclines\x18\a \x01(\x05R\bdeclines\"c\n" +
+	"\rReviewPairing\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\x96\x02\n" +
+	"\tTeamStats\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1a\n" +
+	"\bsubteams\x18\x02 \x03(\tR\bsubteams\x120\n" +
+	"\x03own\x18\x03 \x01(\v2\x1e.reviewer.v1.TeamStatsCountersR\x03own\x126\n" +
+	"\x06rollup\x18\x04 \x01(\v2\x1e.reviewer.v1.TeamStatsCountersR\x06rollup\x12.\n" +
+	"\x13pairing_window_days\x18\x05 \x01(\x05R\x11pairingWindowDays\x126\n" +
+	"\bpairings\x18\x06 \x03(\v2\x1a.reviewer.v1.ReviewPairingR\bpairings\"J\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"L\n" +
+	"\x13SetSeniorityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tseniority\x18\x02 \x01(\tR\tseniority\"w\n" +
+	"\x18SetMaxOpenReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x10max_open_reviews\x18\x02 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01B\x13\n" +
+	"\x11_max_open_reviews\"\x96\x01\n" +
+	"\x12ListReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fonly_pending\x18\x03 \x01(\bR\vonlyPending\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\xa0\x01\n" +
+	"\fReviewCounts\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x05R\x04open\x12\x16\n" +
+	"\x06merged\x18\x03 \x01(\x05R\x06merged\x12\x16\n" +
+	"\x06closed\x18\x04 \x01(\x05R\x06closed\x12\x1a\n" +
+	"\bapproved\x18\x05 \x01(\x05R\bapproved\x12\x1a\n" +
+	"\bdeclined\x18\x06 \x01(\x05R\bdeclined\"\xc1\x01\n" +
+	"\x13ListReviewsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x18.reviewer.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x121\n" +
+	"\x06counts\x18\x04 \x01(\v2\x19.reviewer.v1.ReviewCountsR\x06counts\"\x9c\x04\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12#\n" +
+	"\rlines_changed\x18\a \x01(\x05R\flinesChanged\x12#\n" +
+	"\rfiles_changed\x18\b \x01(\x05R\ffilesChanged\x12\x1e\n" +
+	"\n" +
+	"overloaded\x18\t \x01(\bR\n" +
+	"overloaded\x12\x16\n" +
+	"\x06queued\x18\n" +
+	" \x01(\bR\x06queued\x12\x1c\n" +
+	"\treviewers\x18\v \x03(\tR\treviewers\x12%\n" +
+	"\x0elead_reviewers\x18\f \x03(\tR\rleadReviewers\x12)\n" +
+	"\x10shadow_reviewers\x18\r \x03(\tR\x0fshadowReviewers\x12\x1c\n" +
+	"\tapprovals\x18\x0e \x03(\tR\tapprovals\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversion\"\xd5\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12#\n" +
+	"\rlines_changed\x18\x04 \x01(\x05R\flinesChanged\x12#\n" +
+	"\rfiles_changed\x18\x05 \x01(\x05R\ffilesChanged\"\xbf\x01\n" +
+	"\x18PreviewAssignmentRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05paths\x18\x03 \x03(\tR\x05paths\x12#\n" +
+	"\rlines_changed\x18\x04 \x01(\x05R\flinesChanged\x12#\n" +
+	"\rfiles_changed\x18\x05 \x01(\x05R\ffilesChanged\"I\n" +
+	"\x12ReviewerAssignment\x12\x1f\n" +
+	"\vreviewer_id\x18\x01 \x01(\tR\n" +
+	"reviewerId\x12\x12\n" +
+	"\x04slot\x18\x02 \x01(\tR\x04slot\"\xae\x01\n" +
+	"\x0eCandidateScore\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\x12\x16\n" +
+	"\x06factor\x18\x04 \x01(\x01R\x06factor\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\x12 \n" +
+	"\vprobability\x18\x06 \x01(\x01R\vprobability\"a\n" +
+	"\x11ExcludedCandidate\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xb9\x03\n" +
+	"\x12AssignmentDecision\x12\x1f\n" +
+	"\vdecision_id\x18\x01 \x01(\x03R\n" +
+	"decisionId\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\x12\x12\n" +
+	"\x04slot\x18\x04 \x01(\tR\x04slot\x12\x1a\n" +
+	"\bstrategy\x18\x05 \x01(\tR\bstrategy\x120\n" +
+	"\x14replaced_reviewer_id\x18\x06 \x01(\tR\x12replacedReviewerId\x12#\n" +
+	"\rover_capacity\x18\a \x01(\bR\foverCapacity\x12;\n" +
+	"\n" +
+	"candidates\x18\b \x03(\v2\x1b.reviewer.v1.CandidateScoreR\n" +
+	"candidates\x12:\n" +
+	"\bexcluded\x18\t \x03(\v2\x1e.reviewer.v1.ExcludedCandidateR\bexcluded\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd6\x02\n" +
+	"\x11AssignmentPreview\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12\x1a\n" +
+	"\bstrategy\x18\x03 \x01(\tR\bstrategy\x12=\n" +
+	"\treviewers\x18\x04 \x03(\v2\x1f.reviewer.v1.ReviewerAssignmentR\treviewers\x12\x16\n" +
+	"\x06queued\x18\x05 \x01(\bR\x06queued\x12\x1e\n" +
+	"\n" +
+	"overloaded\x18\x06 \x01(\bR\n" +
+	"overloaded\x125\n" +
+	"\aranking\x18\a \x03(\v2\x1b.reviewer.v1.CandidateScoreR\aranking\x12=\n" +
+	"\tdecisions\x18\b \x03(\v2\x1f.reviewer.v1.AssignmentDecisionR\tdecisions\"{\n" +
+	"\fMergeRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x9e\x01\n" +
+	"\x0fReassignRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"p\n" +
+	"\x10ReassignResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x95\x01\n" +
+	"\x0eDeclineRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"}\n" +
+	"\x0eApproveRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"{\n" +
+	"\fCloseRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\";\n" +
+	"\x11GetHistoryRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\x9e\x02\n" +
+	"\aPREvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12&\n" +
+	"\x0fold_reviewer_id\x18\x04 \x01(\tR\roldReviewerId\x12&\n" +
+	"\x0fnew_reviewer_id\x18\x05 \x01(\tR\rnewReviewerId\x12\x19\n" +
+	"\bactor_id\x18\x06 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"j\n" +
+	"\x12GetHistoryResponse\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12,\n" +
+	"\x06events\x18\x02 \x03(\v2\x14.reviewer.v1.PREventR\x06events\"c\n" +
+	"\x18ExplainAssignmentRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\"\x82\x01\n" +
+	"\x19ExplainAssignmentResponse\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12=\n" +
+	"\tdecisions\x18\x02 \x03(\v2\x1f.reviewer.v1.AssignmentDecisionR\tdecisions\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\xac\x03\n" +
+	"\x19SearchPullRequestsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12;\n" +
+	"\vmerged_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mergedFrom\x127\n" +
+	"\tmerged_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bmergedTo\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\"|\n" +
+	"\x1aSearchPullRequestsResponse\x12=\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x18.reviewer.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\xf0\x03\n" +
+	"\vTeamService\x129\n" +
+	"\aAddTeam\x12\x1b.reviewer.v1.AddTeamRequest\x1a\x11.reviewer.v1.Team\x129\n" +
+	"\aGetTeam\x12\x1b.reviewer.v1.GetTeamRequest\x1a\x11.reviewer.v1.Team\x12?\n" +
+	"\n" +
+	"UpdateTeam\x12\x1e.reviewer.v1.UpdateTeamRequest\x1a\x11.reviewer.v1.Team\x12?\n" +
+	"\n" +
+	"RenameTeam\x12\x1e.reviewer.v1.RenameTeamRequest\x1a\x11.reviewer.v1.Team\x12M\n" +
+	"\n" +
+	"DeleteTeam\x12\x1e.reviewer.v1.DeleteTeamRequest\x1a\x1f.reviewer.v1.DeleteTeamResponse\x12P\n" +
+	"\vGetTeamTree\x12\x1f.reviewer.v1.GetTeamTreeRequest\x1a .reviewer.v1.GetTeamTreeResponse\x12H\n" +
+	"\fGetTeamStats\x12 .reviewer.v1.GetTeamStatsRequest\x1a\x16.reviewer.v1.TeamStats2\xb6\x02\n" +
+	"\vUserService\x12A\n" +
+	"\vSetIsActive\x12\x1f.reviewer.v1.SetIsActiveRequest\x1a\x11.reviewer.v1.User\x12C\n" +
+	"\fSetSeniority\x12 .reviewer.v1.SetSeniorityRequest\x1a\x11.reviewer.v1.User\x12M\n" +
+	"\x11SetMaxOpenReviews\x12%.reviewer.v1.SetMaxOpenReviewsRequest\x1a\x11.reviewer.v1.User\x12P\n" +
+	"\vListReviews\x12\x1f.reviewer.v1.ListReviewsRequest\x1a .reviewer.v1.ListReviewsResponse2\xfe\x06\n" +
+	"\x12PullRequestService\x12T\n" +
+	"\x11CreatePullRequest\x12%.reviewer.v1.CreatePullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12Z\n" +
+	"\x11PreviewAssignment\x12%.reviewer.v1.PreviewAssignmentRequest\x1a\x1e.reviewer.v1.AssignmentPreview\x12<\n" +
+	"\x05Merge\x12\x19.reviewer.v1.MergeRequest\x1a\x18.reviewer.v1.PullRequest\x12G\n" +
+	"\bReassign\x12\x1c.reviewer.v1.ReassignRequest\x1a\x1d.reviewer.v1.ReassignResponse\x12E\n" +
+	"\aDecline\x12\x1b.reviewer.v1.DeclineRequest\x1a\x1d.reviewer.v1.ReassignResponse\x12@\n" +
+	"\aApprove\x12\x1b.reviewer.v1.ApproveRequest\x1a\x18.reviewer.v1.PullRequest\x12<\n" +
+	"\x05Close\x12\x19.reviewer.v1.CloseRequest\x1a\x18.reviewer.v1.PullRequest\x12M\n" +
+	"\n" +
+	"GetHistory\x12\x1e.reviewer.v1.GetHistoryRequest\x1a\x1f.reviewer.v1.GetHistoryResponse\x12b\n" +
+	"\x11ExplainAssignment\x12%.reviewer.v1.ExplainAssignmentRequest\x1a&.reviewer.v1.ExplainAssignmentResponse\x12N\n" +
+	"\x0eGetPullRequest\x12\".reviewer.v1.GetPullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12e\n" +
+	"\x12SearchPullRequests\x12&.reviewer.v1.SearchPullRequestsRequest\x1a'.reviewer.v1.SearchPullRequestsResponseB?Z=github.com/f4ke-n0name/avito/api/proto/reviewer/v1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_reviewer_proto_rawDescOnce sync.Once
+	file_reviewer_v1_reviewer_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_reviewer_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_reviewer_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_reviewer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)))
+	})
+	return file_reviewer_v1_reviewer_proto_rawDescData
+}
+
+var file_reviewer_v1_reviewer_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_reviewer_v1_reviewer_proto_goTypes = []any{
+	(*TeamSettings)(nil),               // 0: reviewer.v1.TeamSettings
+	(*User)(nil),                       // 1: reviewer.v1.User
+	(*TeamMember)(nil),                 // 2: reviewer.v1.TeamMember
+	(*Team)(nil),                       // 3: reviewer.v1.Team
+	(*NewTeamMember)(nil),              // 4: reviewer.v1.NewTeamMember
+	(*AddTeamRequest)(nil),             // 5: reviewer.v1.AddTeamRequest
+	(*GetTeamRequest)(nil),             // 6: reviewer.v1.GetTeamRequest
+	(*UpdateTeamRequest)(nil),          // 7: reviewer.v1.UpdateTeamRequest
+	(*RenameTeamRequest)(nil),          // 8: reviewer.v1.RenameTeamRequest
+	(*DeleteTeamRequest)(nil),          // 9: reviewer.v1.DeleteTeamRequest
+	(*DeleteTeamResponse)(nil),         // 10: reviewer.v1.DeleteTeamResponse
+	(*TeamNode)(nil),                   // 11: reviewer.v1.TeamNode
+	(*GetTeamTreeRequest)(nil),         // 12: reviewer.v1.GetTeamTreeRequest
+	(*GetTeamTreeResponse)(nil),        // 13: reviewer.v1.GetTeamTreeResponse
+	(*GetTeamStatsRequest)(nil),        // 14: reviewer.v1.GetTeamStatsRequest
+	(*TeamStatsCounters)(nil),          // 15: reviewer.v1.TeamStatsCounters
+	(*ReviewPairing)(nil),              // 16: reviewer.v1.ReviewPairing
+	(*TeamStats)(nil),                  // 17: reviewer.v1.TeamStats
+	(*SetIsActiveRequest)(nil),         // 18: reviewer.v1.SetIsActiveRequest
+	(*SetSeniorityRequest)(nil),        // 19: reviewer.v1.SetSeniorityRequest
+	(*SetMaxOpenReviewsRequest)(nil),   // 20: reviewer.v1.SetMaxOpenReviewsRequest
+	(*ListReviewsRequest)(nil),         // 21: reviewer.v1.ListReviewsRequest
+	(*ReviewCounts)(nil),               // 22: reviewer.v1.ReviewCounts
+	(*ListReviewsResponse)(nil),        // 23: reviewer.v1.ListReviewsResponse
+	(*PullRequest)(nil),                // 24: reviewer.v1.PullRequest
+	(*CreatePullRequestRequest)(nil),   // 25: reviewer.v1.CreatePullRequestRequest
+	(*PreviewAssignmentRequest)(nil),   // 26: reviewer.v1.PreviewAssignmentRequest
+	(*ReviewerAssignment)(nil),         // 27: reviewer.v1.ReviewerAssignment
+	(*CandidateScore)(nil),             // 28: reviewer.v1.CandidateScore
+	(*ExcludedCandidate)(nil),          // 29: reviewer.v1.ExcludedCandidate
+	(*AssignmentDecision)(nil),         // 30: reviewer.v1.AssignmentDecision
+	(*AssignmentPreview)(nil),          // 31: reviewer.v1.AssignmentPreview
+	(*MergeRequest)(nil),               // 32: reviewer.v1.MergeRequest
+	(*ReassignRequest)(nil),            // 33: reviewer.v1.ReassignRequest
+	(*ReassignResponse)(nil),           // 34: reviewer.v1.ReassignResponse
+	(*DeclineRequest)(nil),             // 35: reviewer.v1.DeclineRequest
+	(*ApproveRequest)(nil),             // 36: reviewer.v1.ApproveRequest
+	(*CloseRequest)(nil),               // 37: reviewer.v1.CloseRequest
+	(*GetHistoryRequest)(nil),          // 38: reviewer.v1.GetHistoryRequest
+	(*PREvent)(nil),                    // 39: reviewer.v1.PREvent
+	(*GetHistoryResponse)(nil),         // 40: reviewer.v1.GetHistoryResponse
+	(*ExplainAssignmentRequest)(nil),   // 41: reviewer.v1.ExplainAssignmentRequest
+	(*ExplainAssignmentResponse)(nil),  // 42: reviewer.v1.ExplainAssignmentResponse
+	(*GetPullRequestRequest)(nil),      // 43: reviewer.v1.GetPullRequestRequest
+	(*SearchPullRequestsRequest)(nil),  // 44: reviewer.v1.SearchPullRequestsRequest
+	(*SearchPullRequestsResponse)(nil), // 45: reviewer.v1.SearchPullRequestsResponse
+	(*timestamppb.Timestamp)(nil),      // 46: google.protobuf.Timestamp
+}
+var file_reviewer_v1_reviewer_proto_depIdxs = []int32{
+	1,  // 0: reviewer.v1.TeamMember.user:type_name -> reviewer.v1.User
+	0,  // 1: reviewer.v1.Team.settings:type_name -> reviewer.v1.TeamSettings
+	2,  // 2: reviewer.v1.Team.members:type_name -> reviewer.v1.TeamMember
+	4,  // 3: reviewer.v1.AddTeamRequest.members:type_name -> reviewer.v1.NewTeamMember
+	4,  // 4: reviewer.v1.UpdateTeamRequest.add_members:type_name -> reviewer.v1.NewTeamMember
+	0,  // 5: reviewer.v1.UpdateTeamRequest.settings:type_name -> reviewer.v1.TeamSettings
+	11, // 6: reviewer.v1.TeamNode.children:type_name -> reviewer.v1.TeamNode
+	11, // 7: reviewer.v1.GetTeamTreeResponse.teams:type_name -> reviewer.v1.TeamNode
+	15, // 8: reviewer.v1.TeamStats.own:type_name -> reviewer.v1.TeamStatsCounters
+	15, // 9: reviewer.v1.TeamStats.rollup:type_name -> reviewer.v1.TeamStatsCounters
+	16, // 10: reviewer.v1.TeamStats.pairings:type_name -> reviewer.v1.ReviewPairing
+	24, // 11: reviewer.v1.ListReviewsResponse.pull_requests:type_name -> reviewer.v1.PullRequest
+	22, // 12: reviewer.v1.ListReviewsResponse.counts:type_name -> reviewer.v1.ReviewCounts
+	46, // 13: reviewer.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	46, // 14: reviewer.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	28, // 15: reviewer.v1.AssignmentDecision.candidates:type_name -> reviewer.v1.CandidateScore
+	29, // 16: reviewer.v1.AssignmentDecision.excluded:type_name -> reviewer.v1.ExcludedCandidate
+	46, // 17: reviewer.v1.AssignmentDecision.created_at:type_name -> google.protobuf.Timestamp
+	27, // 18: reviewer.v1.AssignmentPreview.reviewers:type_name -> reviewer.v1.ReviewerAssignment
+	28, // 19: reviewer.v1.AssignmentPreview.ranking:type_name -> reviewer.v1.CandidateScore
+	30, // 20: reviewer.v1.AssignmentPreview.decisions:type_name -> reviewer.v1.AssignmentDecision
+	24, // 21: reviewer.v1.ReassignResponse.pull_request:type_name -> reviewer.v1.PullRequest
+	46, // 22: reviewer.v1.PREvent.created_at:type_name -> google.protobuf.Timestamp
+	39, // 23: reviewer.v1.GetHistoryResponse.events:type_name -> reviewer.v1.PREvent
+	30, // 24: reviewer.v1.ExplainAssignmentResponse.decisions:type_name -> reviewer.v1.AssignmentDecision
+	46, // 25: reviewer.v1.SearchPullRequestsRequest.created_from:type_name -> google.protobuf.Timestamp
+	46, // 26: reviewer.v1.SearchPullRequestsRequest.created_to:type_name -> google.protobuf.Timestamp
+	46, // 27: reviewer.v1.SearchPullRequestsRequest.merged_from:type_name -> google.protobuf.Timestamp
+	46, // 28: reviewer.v1.SearchPullRequestsRequest.merged_to:type_name -> google.protobuf.Timestamp
+	24, // 29: reviewer.v1.SearchPullRequestsResponse.pull_requests:type_name -> reviewer.v1.PullRequest
+	5,  // 30: reviewer.v1.TeamService.AddTeam:input_type -> reviewer.v1.AddTeamRequest
+	6,  // 31: reviewer.v1.TeamService.GetTeam:input_type -> reviewer.v1.GetTeamRequest
+	7,  // 32: reviewer.v1.TeamService.UpdateTeam:input_type -> reviewer.v1.UpdateTeamRequest
+	8,  // 33: reviewer.v1.TeamService.RenameTeam:input_type -> reviewer.v1.RenameTeamRequest
+	9,  // 34: reviewer.v1.TeamService.DeleteTeam:input_type -> reviewer.v1.DeleteTeamRequest
+	12, // 35: reviewer.v1.TeamService.GetTeamTree:input_type -> reviewer.v1.GetTeamTreeRequest
+	14, // 36: reviewer.v1.TeamService.GetTeamStats:input_type -> reviewer.v1.GetTeamStatsRequest
+	18, // 37: reviewer.v1.UserService.SetIsActive:input_type -> reviewer.v1.SetIsActiveRequest
+	19, // 38: reviewer.v1.UserService.SetSeniority:input_type -> reviewer.v1.SetSeniorityRequest
+	20, // 39: reviewer.v1.UserService.SetMaxOpenReviews:input_type -> reviewer.v1.SetMaxOpenReviewsRequest
+	21, // 40: reviewer.v1.UserService.ListReviews:input_type -> reviewer.v1.ListReviewsRequest
+	25, // 41: reviewer.v1.PullRequestService.CreatePullRequest:input_type -> reviewer.v1.CreatePullRequestRequest
+	26, // 42: reviewer.v1.PullRequestService.PreviewAssignment:input_type -> reviewer.v1.PreviewAssignmentRequest
+	32, // 43: reviewer.v1.PullRequestService.Merge:input_type -> reviewer.v1.MergeRequest
+	33, // 44: reviewer.v1.PullRequestService.Reassign:input_type -> reviewer.v1.ReassignRequest
+	35, // 45: reviewer.v1.PullRequestService.Decline:input_type -> reviewer.v1.DeclineRequest
+	36, // 46: reviewer.v1.PullRequestService.Approve:input_type -> reviewer.v1.ApproveRequest
+	37, // 47: reviewer.v1.PullRequestService.Close:input_type -> reviewer.v1.CloseRequest
+	38, // 48: reviewer.v1.PullRequestService.GetHistory:input_type -> reviewer.v1.GetHistoryRequest
+	41, // 49: reviewer.v1.PullRequestService.ExplainAssignment:input_type -> reviewer.v1.ExplainAssignmentRequest
+	43, // 50: reviewer.v1.PullRequestService.GetPullRequest:input_type -> reviewer.v1.GetPullRequestRequest
+	44, // 51: reviewer.v1.PullRequestService.SearchPullRequests:input_type -> reviewer.v1.SearchPullRequestsRequest
+	3,  // 52: reviewer.v1.TeamService.AddTeam:output_type -> reviewer.v1.Team
+	3,  // 53: reviewer.v1.TeamService.GetTeam:output_type -> reviewer.v1.Team
+	3,  // 54: reviewer.v1.TeamService.UpdateTeam:output_type -> reviewer.v1.Team
+	3,  // 55: reviewer.v1.TeamService.RenameTeam:output_type -> reviewer.v1.Team
+	10, // 56: reviewer.v1.TeamService.DeleteTeam:output_type -> reviewer.v1.DeleteTeamResponse
+	13, // 57: reviewer.v1.TeamService.GetTeamTree:output_type -> reviewer.v1.GetTeamTreeResponse
+	17, // 58: reviewer.v1.TeamService.GetTeamStats:output_type -> reviewer.v1.TeamStats
+	1,  // 59: reviewer.v1.UserService.SetIsActive:output_type -> reviewer.v1.User
+	1,  // 60: reviewer.v1.UserService.SetSeniority:output_type -> reviewer.v1.User
+	1,  // 61: reviewer.v1.UserService.SetMaxOpenReviews:output_type -> reviewer.v1.User
+	23, // 62: reviewer.v1.UserService.ListReviews:output_type -> reviewer.v1.ListReviewsResponse
+	24, // 63: reviewer.v1.PullRequestService.CreatePullRequest:output_type -> reviewer.v1.PullRequest
+	31, // 64: reviewer.v1.PullRequestService.PreviewAssignment:output_type -> reviewer.v1.AssignmentPreview
+	24, // 65: reviewer.v1.PullRequestService.Merge:output_type -> reviewer.v1.PullRequest
+	34, // 66: reviewer.v1.PullRequestService.Reassign:output_type -> reviewer.v1.ReassignResponse
+	34, // 67: reviewer.v1.PullRequestService.Decline:output_type -> reviewer.v1.ReassignResponse
+	24, // 68: reviewer.v1.PullRequestService.Approve:output_type -> reviewer.v1.PullRequest
+	24, // 69: reviewer.v1.PullRequestService.Close:output_type -> reviewer.v1.PullRequest
+	40, // 70: reviewer.v1.PullRequestService.GetHistory:output_type -> reviewer.v1.GetHistoryResponse
+	42, // 71: reviewer.v1.PullRequestService.ExplainAssignment:output_type -> reviewer.v1.ExplainAssignmentResponse
+	24, // 72: reviewer.v1.PullRequestService.GetPullRequest:output_type -> reviewer.v1.PullRequest
+	45, // 73: reviewer.v1.PullRequestService.SearchPullRequests:output_type -> reviewer.v1.SearchPullRequestsResponse
+	52, // [52:74] is the sub-list for method output_type
+	30, // [30:52] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_reviewer_proto_init() }
+func file_reviewer_v1_reviewer_proto_init() {
+	if File_reviewer_v1_reviewer_proto != nil {
+		return
+	}
+	file_reviewer_v1_reviewer_proto_msgTypes[1].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[4].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[7].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[20].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[32].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[33].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[35].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[36].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[37].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   46,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_reviewer_v1_reviewer_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_reviewer_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_reviewer_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_reviewer_proto = out.File
+	file_reviewer_v1_reviewer_proto_goTypes = nil
+	file_reviewer_v1_reviewer_proto_depIdxs = nil
+}
@@ -0,0 +1,16 @@
+#!/bin/sh
+# Runs once, when the Postgres container initializes an empty data directory.
+# Applies the migrations as the superuser and creates the login role the
+# service connects with. That role is a member of avito_app and is therefore
+# subject to row-level security.
+set -e
+
+for f in /migrations/*.sql; do
+  echo "Applying $f"
+  psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" -f "$f"
+done
+
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" \
+  -v app_user="$APP_DB_USER" -v app_password="$APP_DB_PASSWORD" <<'SQL'
+CREATE ROLE :"app_user" LOGIN PASSWORD :'app_password' IN ROLE avito_app;
+SQL
@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+)
+
+// principal returns the caller attached by authenticate.
+func principal(ctx context.Context) entities.Principal {
+	p, _ := services.PrincipalFromContext(ctx)
+	return p
+}
+
+func requireAdmin(ctx context.Context) error {
+	if !principal(ctx).IsAdmin() {
+		return permissionDenied()
+	}
+	return nil
+}
+
+// requireTeams lets admins and administrators of every given team through.
+func requireTeams(ctx context.Context, teams ...string) error {
+	p := principal(ctx)
+	for _, t := range teams {
+		if !p.ManagesTeam(t) {
+			return permissionDenied()
+		}
+	}
+	return nil
+}
+
+// managesUser reports whether the caller administers the user's primary team.
+func (s *Server) managesUser(ctx context.Context, userID string) bool {
+	p := principal(ctx)
+	if p.IsAdmin() {
+		return true
+	}
+	if p.Role != entities.RoleTeamAdmin {
+		return false
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	return err == nil && u != nil && p.ManagesTeam(u.TeamName)
+}
+
+// requireUserAdmin lets through callers that administer the user's primary
+// team. A team administrator naming an unknown user gets NOT_FOUND.
+func (s *Server) requireUserAdmin(ctx context.Context, userID string) error {
+	p := principal(ctx)
+	if p.IsAdmin() {
+		return nil
+	}
+	if p.Role != entities.RoleTeamAdmin {
+		return permissionDenied()
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return errors.ErrUserNotFound
+	}
+	if !p.ManagesTeam(u.TeamName) {
+		return permissionDenied()
+	}
+	return nil
+}
+
+// requireNewOrManagedUser lets through users that do not exist yet and users
+// whose primary team the caller administers.
+func (s *Server) requireNewOrManagedUser(ctx context.Context, userID string) error {
+	p := principal(ctx)
+	if p.IsAdmin() {
+		return nil
+	}
+	u, err := s.users.GetByID(ctx, userID)
+	if stderrors.Is(err, errors.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u != nil && !p.ManagesTeam(u.TeamName) {
+		return permissionDenied()
+	}
+	return nil
+}
+
+// actsFor reports whether the caller may act on behalf of the user: as the
+// user, or as an administrator of the user's primary team.
+func (s *Server) actsFor(ctx context.Context, userID string) bool {
+	return principal(ctx).UserID == userID || s.managesUser(ctx, userID)
+}
+
+// authorizePR lets admins, the PR author and the given extra users through.
+func (s *Server) authorizePR(ctx context.Context, prID string, extra ...string) error {
+	p := principal(ctx)
+	if p.IsAdmin() {
+		return nil
+	}
+	pr, err := s.pr.GetPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if p.UserID == "" {
+		return permissionDenied()
+	}
+	if p.UserID == pr.AuthorID {
+		return nil
+	}
+	for _, id := range extra {
+		if id == p.UserID {
+			return nil
+		}
+	}
+	return permissionDenied()
+}
+
+// caller returns the user id of the caller, which decline and approve act as.
+func caller(ctx context.Context) (string, error) {
+	id := services.ActorFromContext(ctx)
+	if id == "" {
+		return "", unauthenticated("caller identity is required")
+	}
+	return id, nil
+}
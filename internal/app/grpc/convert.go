@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFrom(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func toUser(u *entities.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:         u.UserID,
+		Username:       u.Username,
+		IsActive:       u.IsActive,
+		TeamName:       u.TeamName,
+		Seniority:      string(u.Seniority),
+		MaxOpenReviews: int32Ptr(u.MaxOpenReviews),
+	}
+}
+
+func toTeamSettings(s entities.TeamSettings) *reviewerv1.TeamSettings {
+	return &reviewerv1.TeamSettings{
+		ReviewersCount:     int32(s.ReviewersCount),
+		EscalateToParent:   s.EscalateToParent,
+		LeadReviewMinLines: int32(s.LeadReviewMinLines),
+		LeadReviewMinFiles: int32(s.LeadReviewMinFiles),
+		MentorshipRequired: s.MentorshipRequired,
+		ShadowJuniors:      s.ShadowJuniors,
+		Strategy:           string(s.Strategy),
+		PairingWindowDays:  int32(s.PairingWindowDays),
+		PairingDecay:       s.PairingDecay,
+		MaxOpenReviews:     int32(s.MaxOpenReviews),
+		OverloadPolicy:     string(s.OverloadPolicy),
+	}
+}
+
+func toTeam(t *entities.Team) *reviewerv1.Team {
+	out := &reviewerv1.Team{
+		TeamName:   t.TeamName,
+		ParentTeam: t.ParentTeam,
+		Settings:   toTeamSettings(t.Settings),
+	}
+	for i := range t.Members {
+		m := &t.Members[i]
+		out.Members = append(out.Members, &reviewerv1.TeamMember{
+			User:         toUser(&m.User),
+			IsPrimary:    m.IsPrimary,
+			ReviewWeight: m.ReviewWeight,
+			Role:         string(m.Role),
+			OpenReviews:  int32(m.OpenReviews),
+		})
+	}
+	return out
+}
+
+func toTeamNodes(nodes []entities.TeamNode) []*reviewerv1.TeamNode {
+	out := make([]*reviewerv1.TeamNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, &reviewerv1.TeamNode{TeamName: n.TeamName, Children: toTeamNodes(n.Children)})
+	}
+	return out
+}
+
+func toCounters(c entities.TeamStatsCounters) *reviewerv1.TeamStatsCounters {
+	return &reviewerv1.TeamStatsCounters{
+		Members:           int32(c.Members),
+		ActiveMembers:     int32(c.ActiveMembers),
+		OpenPrs:           int32(c.OpenPRs),
+		MergedPrs:         int32(c.MergedPRs),
+		ClosedPrs:         int32(c.ClosedPRs),
+		ReviewAssignments: int32(c.ReviewAssignments),
+		Declines:          int32(c.Declines),
+	}
+}
+
+func toTeamStats(s *entities.TeamStats) *reviewerv1.TeamStats {
+	out := &reviewerv1.TeamStats{
+		TeamName:          s.TeamName,
+		Subteams:          s.Subteams,
+		Own:               toCounters(s.Own),
+		Rollup:            toCounters(s.Rollup),
+		PairingWindowDays: int32(s.PairingWindowDays),
+	}
+	for _, p := range s.Pairings {
+		out.Pairings = append(out.Pairings, &reviewerv1.ReviewPairing{
+			AuthorId:   p.AuthorID,
+			ReviewerId: p.ReviewerID,
+			Count:      int32(p.Count),
+		})
+	}
+	return out
+}
+
+func toPullRequest(pr *entities.PullRequest) *reviewerv1.PullRequest {
+	return &reviewerv1.PullRequest{
+		PullRequestId:   pr.PRID,
+		Name:            pr.Name,
+		AuthorId:        pr.AuthorID,
+		Status:          string(pr.Status),
+		CreatedAt:       timestamppb.New(pr.CreatedAt),
+		MergedAt:        timestamp(pr.MergedAt),
+		LinesChanged:    int32(pr.LinesChanged),
+		FilesChanged:    int32(pr.FilesChanged),
+		Overloaded:      pr.Overloaded,
+		Queued:          pr.Queued,
+		Reviewers:       pr.Reviewers,
+		LeadReviewers:   pr.LeadReviewers,
+		ShadowReviewers: pr.ShadowReviewers,
+		Approvals:       pr.Approvals,
+		Version:         pr.Version,
+	}
+}
+
+func toPullRequests(prs []entities.PullRequest) []*reviewerv1.PullRequest {
+	out := make([]*reviewerv1.PullRequest, 0, len(prs))
+	for i := range prs {
+		out = append(out, toPullRequest(&prs[i]))
+	}
+	return out
+}
+
+func toEvent(e entities.PREvent) *reviewerv1.PREvent {
+	return &reviewerv1.PREvent{
+		EventId:       e.EventID,
+		PullRequestId: e.PRID,
+		Type:          string(e.Type),
+		OldReviewerId: e.OldReviewerID,
+		NewReviewerId: e.NewReviewerID,
+		ActorId:       e.ActorID,
+		Reason:        e.Reason,
+		CreatedAt:     timestamppb.New(e.CreatedAt),
+	}
+}
+
+func toCandidates(scores []entities.CandidateScore) []*reviewerv1.CandidateScore {
+	out := make([]*reviewerv1.CandidateScore, 0, len(scores))
+	for _, c := range scores {
+		out = append(out, &reviewerv1.CandidateScore{
+			UserId:      c.UserID,
+			TeamName:    c.TeamName,
+			Weight:      c.Weight,
+			Factor:      c.Factor,
+			Score:       c.Score,
+			Probability: c.Probability,
+		})
+	}
+	return out
+}
+
+func toDecision(d entities.AssignmentDecision) *reviewerv1.AssignmentDecision {
+	out := &reviewerv1.AssignmentDecision{
+		DecisionId:         d.DecisionID,
+		PullRequestId:      d.PRID,
+		ReviewerId:         d.ReviewerID,
+		Slot:               string(d.Slot),
+		Strategy:           string(d.Strategy),
+		ReplacedReviewerId: d.ReplacedReviewerID,
+		OverCapacity:       d.OverCapacity,
+		Candidates:         toCandidates(d.Candidates),
+		CreatedAt:          timestamppb.New(d.CreatedAt),
+	}
+	for _, e := range d.Excluded {
+		out.Excluded = append(out.Excluded, &reviewerv1.ExcludedCandidate{
+			UserId:   e.UserID,
+			TeamName: e.TeamName,
+			Reason:   string(e.Reason),
+		})
+	}
+	return out
+}
+
+func toDecisions(decisions []entities.AssignmentDecision) []*reviewerv1.AssignmentDecision {
+	out := make([]*reviewerv1.AssignmentDecision, 0, len(decisions))
+	for _, d := range decisions {
+		out = append(out, toDecision(d))
+	}
+	return out
+}
+
+func toPreview(p *entities.AssignmentPreview) *reviewerv1.AssignmentPreview {
+	out := &reviewerv1.AssignmentPreview{
+		AuthorId:   p.AuthorID,
+		TeamName:   p.TeamName,
+		Strategy:   string(p.Strategy),
+		Queued:     p.Queued,
+		Overloaded: p.Overloaded,
+		Ranking:    toCandidates(p.Ranking),
+		Decisions:  toDecisions(p.Decisions),
+	}
+	for _, r := range p.Reviewers {
+		out.Reviewers = append(out.Reviewers, &reviewerv1.ReviewerAssignment{ReviewerId: r.ReviewerID, Slot: string(r.Slot)})
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	stderrors "errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+// domainCodes maps domain errors to status codes. Entries are matched with
+// errors.Is in order; the error's own message becomes the status message.
+var domainCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{errors.ErrPRNotFound, codes.NotFound},
+	{errors.ErrUserNotFound, codes.NotFound},
+	{errors.ErrTeamNotFound, codes.NotFound},
+	{errors.ErrParentNotFound, codes.NotFound},
+	{errors.ErrTokenNotFound, codes.NotFound},
+	{errors.ErrPRExists, codes.AlreadyExists},
+	{errors.ErrTeamExists, codes.AlreadyExists},
+	{errors.ErrMemberConflict, codes.FailedPrecondition},
+	{errors.ErrTeamCycle, codes.FailedPrecondition},
+	{errors.ErrTeamHasOpenPRs, codes.FailedPrecondition},
+	{errors.ErrPRAlreadyMerged, codes.FailedPrecondition},
+	{errors.ErrPRClosed, codes.FailedPrecondition},
+	{errors.ErrNoSuchReviewer, codes.FailedPrecondition},
+	{errors.ErrReviewerNotInTeam, codes.FailedPrecondition},
+	{errors.ErrReviewerInactive, codes.FailedPrecondition},
+	{errors.ErrNoCandidates, codes.FailedPrecondition},
+	{errors.ErrNoLeadCandidate, codes.FailedPrecondition},
+	{errors.ErrLeadApprovalRequired, codes.FailedPrecondition},
+	{errors.ErrReviewersAtCapacity, codes.ResourceExhausted},
+	{errors.ErrVersionMismatch, codes.Aborted},
+	{errors.ErrInvalidTargetTeam, codes.InvalidArgument},
+	{errors.ErrInvalidCursor, codes.InvalidArgument},
+	{errors.ErrUnauthenticated, codes.Unauthenticated},
+	{errors.ErrForbidden, codes.PermissionDenied},
+}
+
+// translateErrors turns errors returned by the services into statuses.
+// Errors that are not domain errors are logged and reported as Internal
+// without details.
+func translateErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+	for _, e := range domainCodes {
+		if !stderrors.Is(err, e.err) {
+			continue
+		}
+		msg := e.err.Error()
+		var conflict *errors.MemberConflictError
+		if stderrors.As(err, &conflict) {
+			msg = conflict.Error()
+		}
+		return nil, status.Error(e.code, msg)
+	}
+	log.Printf("grpc call %s failed: %v", info.FullMethod, err)
+	return nil, status.Error(codes.Internal, "internal error")
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
+
+func permissionDenied() error {
+	return status.Error(codes.PermissionDenied, "not allowed for this caller")
+}
+
+func unauthenticated(msg string) error {
+	return status.Error(codes.Unauthenticated, msg)
+}
+
+// required rejects empty values of required fields.
+func required(fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return invalidArgument(fields[i] + " is required")
+		}
+	}
+	return nil
+}
+
+// oneOf rejects values outside allowed; empty values are left to defaults.
+func oneOf(field, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return invalidArgument(field + " must be one of " + strings.Join(allowed, ", "))
+}
@@ -0,0 +1,216 @@
+package grpc
+
+import (
+	"context"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+)
+
+type pullRequestServer struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+	*Server
+}
+
+func prSize(lines, files int32) (entities.PRSize, error) {
+	if lines < 0 || files < 0 {
+		return entities.PRSize{}, invalidArgument("lines_changed and files_changed must not be negative")
+	}
+	return entities.PRSize{LinesChanged: int(lines), FilesChanged: int(files)}, nil
+}
+
+// expectVersion scopes a mutation to the version the client read, if it sent one.
+func expectVersion(ctx context.Context, version *int64) context.Context {
+	if version == nil {
+		return ctx
+	}
+	return services.WithExpectedVersion(ctx, *version)
+}
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.PullRequest, error) {
+	if err := required("pull_request_id", req.GetPullRequestId(), "pull_request_name", req.GetPullRequestName()); err != nil {
+		return nil, err
+	}
+	size, err := prSize(req.GetLinesChanged(), req.GetFilesChanged())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAuthorId() == "" {
+		// The service defaults the author to the caller.
+		if principal(ctx).UserID == "" {
+			return nil, invalidArgument("author_id is required for callers without a user")
+		}
+	} else if !s.actsFor(ctx, req.GetAuthorId()) {
+		return nil, permissionDenied()
+	}
+
+	pr, err := s.pr.CreatePR(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId(), size)
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) PreviewAssignment(ctx context.Context, req *reviewerv1.PreviewAssignmentRequest) (*reviewerv1.AssignmentPreview, error) {
+	files := req.GetFilesChanged()
+	if files == 0 {
+		files = int32(len(req.GetPaths()))
+	}
+	size, err := prSize(req.GetLinesChanged(), files)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := s.pr.PreviewAssignment(ctx, req.GetPullRequestId(), req.GetAuthorId(), size)
+	if err != nil {
+		return nil, err
+	}
+	return toPreview(preview), nil
+}
+
+func (s *pullRequestServer) Merge(ctx context.Context, req *reviewerv1.MergeRequest) (*reviewerv1.PullRequest, error) {
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizePR(ctx, req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.pr.Merge(expectVersion(ctx, req.ExpectedVersion), req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) Reassign(ctx context.Context, req *reviewerv1.ReassignRequest) (*reviewerv1.ReassignResponse, error) {
+	if err := required("pull_request_id", req.GetPullRequestId(), "old_user_id", req.GetOldUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizePR(ctx, req.GetPullRequestId(), req.GetOldUserId()); err != nil {
+		return nil, err
+	}
+
+	pr, newID, err := s.pr.ReplaceReviewer(expectVersion(ctx, req.ExpectedVersion), req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ReassignResponse{PullRequest: toPullRequest(pr), ReplacedBy: newID}, nil
+}
+
+func (s *pullRequestServer) Decline(ctx context.Context, req *reviewerv1.DeclineRequest) (*reviewerv1.ReassignResponse, error) {
+	callerID, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("pull_request_id", req.GetPullRequestId(), "reason", req.GetReason()); err != nil {
+		return nil, err
+	}
+
+	pr, newID, err := s.pr.Decline(expectVersion(ctx, req.ExpectedVersion), req.GetPullRequestId(), callerID, req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ReassignResponse{PullRequest: toPullRequest(pr), ReplacedBy: newID}, nil
+}
+
+func (s *pullRequestServer) Approve(ctx context.Context, req *reviewerv1.ApproveRequest) (*reviewerv1.PullRequest, error) {
+	callerID, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.pr.Approve(expectVersion(ctx, req.ExpectedVersion), req.GetPullRequestId(), callerID)
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) Close(ctx context.Context, req *reviewerv1.CloseRequest) (*reviewerv1.PullRequest, error) {
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	if err := s.authorizePR(ctx, req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.pr.Close(expectVersion(ctx, req.ExpectedVersion), req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) GetHistory(ctx context.Context, req *reviewerv1.GetHistoryRequest) (*reviewerv1.GetHistoryResponse, error) {
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	events, err := s.pr.History(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+
+	out := &reviewerv1.GetHistoryResponse{PullRequestId: req.GetPullRequestId()}
+	for _, e := range events {
+		out.Events = append(out.Events, toEvent(e))
+	}
+	return out, nil
+}
+
+func (s *pullRequestServer) ExplainAssignment(ctx context.Context, req *reviewerv1.ExplainAssignmentRequest) (*reviewerv1.ExplainAssignmentResponse, error) {
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	decisions, err := s.pr.ExplainAssignment(ctx, req.GetPullRequestId(), req.GetReviewerId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ExplainAssignmentResponse{
+		PullRequestId: req.GetPullRequestId(),
+		Decisions:     toDecisions(decisions),
+	}, nil
+}
+
+func (s *pullRequestServer) GetPullRequest(ctx context.Context, req *reviewerv1.GetPullRequestRequest) (*reviewerv1.PullRequest, error) {
+	if err := required("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	pr, err := s.pr.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) SearchPullRequests(ctx context.Context, req *reviewerv1.SearchPullRequestsRequest) (*reviewerv1.SearchPullRequestsResponse, error) {
+	if err := oneOf("status", req.GetStatus(), "OPEN", "MERGED", "CLOSED"); err != nil {
+		return nil, err
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > 100 {
+		return nil, invalidArgument("limit must be between 1 and 100")
+	}
+
+	page, err := s.pr.Search(ctx, entities.PRSearchFilter{
+		AuthorID:    req.GetAuthorId(),
+		ReviewerID:  req.GetReviewerId(),
+		TeamName:    req.GetTeamName(),
+		Status:      entities.PRStatus(req.GetStatus()),
+		CreatedFrom: timeFrom(req.GetCreatedFrom()),
+		CreatedTo:   timeFrom(req.GetCreatedTo()),
+		MergedFrom:  timeFrom(req.GetMergedFrom()),
+		MergedTo:    timeFrom(req.GetMergedTo()),
+		Limit:       int(req.GetLimit()),
+	}, req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SearchPullRequestsResponse{
+		PullRequests: toPullRequests(page.PullRequests),
+		NextCursor:   page.NextCursor,
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Package grpc serves the reviewer API over gRPC for internal tooling. It is
+// backed by the same services as the HTTP server and enforces the same access
+// rules.
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+type Server struct {
+	pr    interfaces.PRService
+	users interfaces.UserService
+	teams interfaces.TeamService
+	authn interfaces.Authenticator
+}
+
+func NewServer(
+	pr interfaces.PRService,
+	users interfaces.UserService,
+	teams interfaces.TeamService,
+	authn interfaces.Authenticator,
+) *Server {
+	return &Server{pr: pr, users: users, teams: teams, authn: authn}
+}
+
+// ServerOptions returns the interceptors every call must pass: errors are
+// translated to status codes and callers are authenticated.
+func (s *Server) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(translateErrors, s.authenticate),
+	}
+}
+
+// Register adds the team, user and pull request services to g.
+func (s *Server) Register(g grpc.ServiceRegistrar) {
+	reviewerv1.RegisterTeamServiceServer(g, &teamServer{Server: s})
+	reviewerv1.RegisterUserServiceServer(g, &userServer{Server: s})
+	reviewerv1.RegisterPullRequestServiceServer(g, &pullRequestServer{Server: s})
+}
+
+// authenticate resolves the bearer token of the authorization metadata into
+// a principal on the context.
+func (s *Server) authenticate(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "bearer token is required")
+	}
+	secret, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "bearer token is required")
+	}
+	p, err := s.authn.Authenticate(ctx, strings.TrimSpace(secret))
+	if err != nil {
+		return nil, err
+	}
+	return handler(services.WithPrincipal(ctx, *p), req)
+}
@@ -0,0 +1,608 @@
+package grpc_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	grpcapp "github.com/f4ke-n0name/avito/internal/app/grpc"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+)
+
+// backend holds the fake services behind a test server. err is returned by
+// the team, user and PR operations; the lookups used for access checks
+// always succeed.
+type backend struct {
+	err error
+}
+
+type clients struct {
+	teams reviewerv1.TeamServiceClient
+	users reviewerv1.UserServiceClient
+	prs   reviewerv1.PullRequestServiceClient
+}
+
+// newClients serves the gRPC API over an in-memory listener backed by b.
+func newClients(t *testing.T, b *backend) clients {
+	t.Helper()
+	s := grpcapp.NewServer(fakePRs{b}, fakeUsers{b}, fakeTeams{b}, fakeAuth{})
+	g := grpc.NewServer(s.ServerOptions()...)
+	s.Register(g)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return clients{
+		teams: reviewerv1.NewTeamServiceClient(conn),
+		users: reviewerv1.NewUserServiceClient(conn),
+		prs:   reviewerv1.NewPullRequestServiceClient(conn),
+	}
+}
+
+// as returns a context that sends token as the bearer credential.
+func as(t *testing.T, token string) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func checkCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Errorf("code = %s (%v), want %s", got, err, want)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	c := newClients(t, &backend{})
+	req := &reviewerv1.GetTeamRequest{TeamName: "backend"}
+
+	_, err := c.teams.GetTeam(context.Background(), req)
+	checkCode(t, err, codes.Unauthenticated)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic admin")
+	_, err = c.teams.GetTeam(ctx, req)
+	checkCode(t, err, codes.Unauthenticated)
+
+	_, err = c.teams.GetTeam(as(t, "bogus"), req)
+	checkCode(t, err, codes.Unauthenticated)
+
+	team, err := c.teams.GetTeam(as(t, "member"), req)
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if team.GetTeamName() != "backend" || len(team.GetMembers()) != 1 {
+		t.Errorf("team = %v", team)
+	}
+}
+
+// TestErrorCodes checks that domain errors, also when wrapped, reach the
+// client as their status codes with the error's message.
+func TestErrorCodes(t *testing.T) {
+	b := &backend{}
+	c := newClients(t, b)
+	conflict := &errors.MemberConflictError{Conflicts: []errors.MemberConflict{{UserID: "u1", TeamName: "frontend"}}}
+
+	tests := []struct {
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{errors.ErrTeamNotFound, codes.NotFound, errors.ErrTeamNotFound.Error()},
+		{fmt.Errorf("loading team: %w", errors.ErrTeamNotFound), codes.NotFound, errors.ErrTeamNotFound.Error()},
+		{errors.ErrTeamExists, codes.AlreadyExists, errors.ErrTeamExists.Error()},
+		{conflict, codes.FailedPrecondition, conflict.Error()},
+		{errors.ErrTeamHasOpenPRs, codes.FailedPrecondition, errors.ErrTeamHasOpenPRs.Error()},
+		{errors.ErrReviewersAtCapacity, codes.ResourceExhausted, errors.ErrReviewersAtCapacity.Error()},
+		{errors.ErrVersionMismatch, codes.Aborted, errors.ErrVersionMismatch.Error()},
+		{errors.ErrInvalidTargetTeam, codes.InvalidArgument, errors.ErrInvalidTargetTeam.Error()},
+		{errors.ErrForbidden, codes.PermissionDenied, errors.ErrForbidden.Error()},
+		{stderrors.New("connection reset"), codes.Internal, "internal error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			b.err = tt.err
+			_, err := c.teams.GetTeam(as(t, "admin"), &reviewerv1.GetTeamRequest{TeamName: "backend"})
+			checkCode(t, err, tt.code)
+			if got := status.Convert(err).Message(); got != tt.msg {
+				t.Errorf("message = %q, want %q", got, tt.msg)
+			}
+		})
+	}
+}
+
+func TestTeamRPCs(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		err   error
+		call  func(context.Context, clients) error
+		code  codes.Code
+	}{
+		{"admin adds a team", "admin", nil, addTeam("backend"), codes.OK},
+		{"team admin cannot add a team", "lead", nil, addTeam("backend"), codes.PermissionDenied},
+		{"team name is required", "admin", nil, addTeam(""), codes.InvalidArgument},
+		{"existing team", "admin", errors.ErrTeamExists, addTeam("backend"), codes.AlreadyExists},
+		{"member conflict", "admin", &errors.MemberConflictError{}, addTeam("backend"), codes.FailedPrecondition},
+		{"bad member role", "admin", nil, func(ctx context.Context, c clients) error {
+			_, err := c.teams.AddTeam(ctx, &reviewerv1.AddTeamRequest{
+				TeamName: "backend",
+				Members:  []*reviewerv1.NewTeamMember{{UserId: "u1", Username: "u1", Role: "owner"}},
+			})
+			return err
+		}, codes.InvalidArgument},
+		{"team admin updates own team", "lead", nil, updateTeam("backend", 2), codes.OK},
+		{"team admin cannot update another team", "lead", nil, updateTeam("frontend", 2), codes.PermissionDenied},
+		{"bad settings", "admin", nil, updateTeam("backend", 0), codes.InvalidArgument},
+		{"admin adds a user of another team", "admin", nil, addMember("backend", "u2"), codes.OK},
+		{"team admin adds a new user", "lead", nil, addMember("backend", "u7"), codes.OK},
+		{"team admin adds a user of the team", "lead", nil, addMember("backend", "u1"), codes.OK},
+		{"team admin cannot add a user of another team", "lead", nil, addMember("backend", "u2"), codes.PermissionDenied},
+		{"admin of another team cannot add members", "frontend-lead", nil, addMember("backend", "u7"), codes.PermissionDenied},
+		{"member cannot add members", "member", nil, addMember("backend", "u7"), codes.PermissionDenied},
+		{"team admin cannot move a team under an unmanaged parent", "lead", nil, reparent("backend", "frontend"), codes.PermissionDenied},
+		{"team admin detaches own team", "lead", nil, reparent("backend", ""), codes.OK},
+		{"admin moves a team under another", "admin", nil, reparent("backend", "frontend"), codes.OK},
+		{"team admin of the parent only", "frontend-lead", nil, reparent("backend", "frontend"), codes.PermissionDenied},
+		{"rename to a taken name", "lead", errors.ErrTeamExists, func(ctx context.Context, c clients) error {
+			_, err := c.teams.RenameTeam(ctx, &reviewerv1.RenameTeamRequest{TeamName: "backend", NewName: "frontend"})
+			return err
+		}, codes.AlreadyExists},
+		{"delete into an unmanaged team", "lead", nil, deleteTeam("backend", "frontend"), codes.PermissionDenied},
+		{"delete with open PRs", "lead", errors.ErrTeamHasOpenPRs, deleteTeam("backend", ""), codes.FailedPrecondition},
+		{"missing team", "member", errors.ErrTeamNotFound, func(ctx context.Context, c clients) error {
+			_, err := c.teams.GetTeamStats(ctx, &reviewerv1.GetTeamStatsRequest{TeamName: "ghost"})
+			return err
+		}, codes.NotFound},
+		{"team tree", "member", nil, func(ctx context.Context, c clients) error {
+			_, err := c.teams.GetTeamTree(ctx, &reviewerv1.GetTeamTreeRequest{})
+			return err
+		}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClients(t, &backend{err: tt.err})
+			checkCode(t, tt.call(as(t, tt.token), c), tt.code)
+		})
+	}
+}
+
+func addTeam(name string) func(context.Context, clients) error {
+	return func(ctx context.Context, c clients) error {
+		team, err := c.teams.AddTeam(ctx, &reviewerv1.AddTeamRequest{
+			TeamName: name,
+			Members:  []*reviewerv1.NewTeamMember{{UserId: "u1", Username: "alice", IsActive: true, Role: "lead"}},
+		})
+		if err == nil && (team.GetTeamName() != name || team.GetMembers()[0].GetRole() != "lead") {
+			return fmt.Errorf("unexpected team %v", team)
+		}
+		return err
+	}
+}
+
+func updateTeam(name string, reviewers int32) func(context.Context, clients) error {
+	return func(ctx context.Context, c clients) error {
+		team, err := c.teams.UpdateTeam(ctx, &reviewerv1.UpdateTeamRequest{
+			TeamName: name,
+			Settings: &reviewerv1.TeamSettings{ReviewersCount: reviewers},
+		})
+		if err == nil && team.GetSettings().GetReviewersCount() != reviewers {
+			return fmt.Errorf("unexpected settings %v", team.GetSettings())
+		}
+		return err
+	}
+}
+
+func addMember(team, userID string) func(context.Context, clients) error {
+	return func(ctx context.Context, c clients) error {
+		_, err := c.teams.UpdateTeam(ctx, &reviewerv1.UpdateTeamRequest{
+			TeamName:   team,
+			AddMembers: []*reviewerv1.NewTeamMember{{UserId: userID, Username: "eve"}},
+		})
+		return err
+	}
+}
+
+func reparent(name, parent string) func(context.Context, clients) error {
+	return func(ctx context.Context, c clients) error {
+		_, err := c.teams.UpdateTeam(ctx, &reviewerv1.UpdateTeamRequest{TeamName: name, ParentTeam: &parent})
+		return err
+	}
+}
+
+func deleteTeam(name, target string) func(context.Context, clients) error {
+	return func(ctx context.Context, c clients) error {
+		_, err := c.teams.DeleteTeam(ctx, &reviewerv1.DeleteTeamRequest{TeamName: name, TargetTeam: target})
+		return err
+	}
+}
+
+func TestUserRPCs(t *testing.T) {
+	setActive := func(id string) func(context.Context, clients) error {
+		return func(ctx context.Context, c clients) error {
+			u, err := c.users.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: id, IsActive: true})
+			if err == nil && u.GetUserId() != id {
+				return fmt.Errorf("unexpected user %v", u)
+			}
+			return err
+		}
+	}
+	tests := []struct {
+		name  string
+		token string
+		err   error
+		call  func(context.Context, clients) error
+		code  codes.Code
+	}{
+		{"admin", "admin", nil, setActive("u2"), codes.OK},
+		{"team admin of the user's team", "lead", nil, setActive("u1"), codes.OK},
+		{"team admin of another team", "lead", nil, setActive("u2"), codes.PermissionDenied},
+		{"unknown user", "lead", nil, setActive("ghost"), codes.NotFound},
+		{"member", "member", nil, setActive("u1"), codes.PermissionDenied},
+		{"user id is required", "admin", nil, setActive(""), codes.InvalidArgument},
+		{"user removed meanwhile", "admin", errors.ErrUserNotFound, setActive("u1"), codes.NotFound},
+		{"bad seniority", "admin", nil, func(ctx context.Context, c clients) error {
+			_, err := c.users.SetSeniority(ctx, &reviewerv1.SetSeniorityRequest{UserId: "u1", Seniority: "principal"})
+			return err
+		}, codes.InvalidArgument},
+		{"limit below one", "admin", nil, func(ctx context.Context, c clients) error {
+			limit := int32(0)
+			_, err := c.users.SetMaxOpenReviews(ctx, &reviewerv1.SetMaxOpenReviewsRequest{UserId: "u1", MaxOpenReviews: &limit})
+			return err
+		}, codes.InvalidArgument},
+		{"list reviews", "member", nil, func(ctx context.Context, c clients) error {
+			resp, err := c.users.ListReviews(ctx, &reviewerv1.ListReviewsRequest{UserId: "u1"})
+			if err == nil && resp.GetCounts().GetOpen() != 1 {
+				return fmt.Errorf("unexpected counts %v", resp.GetCounts())
+			}
+			return err
+		}, codes.OK},
+		{"page size too large", "member", nil, func(ctx context.Context, c clients) error {
+			_, err := c.users.ListReviews(ctx, &reviewerv1.ListReviewsRequest{UserId: "u1", Limit: 101})
+			return err
+		}, codes.InvalidArgument},
+		{"bad cursor", "member", errors.ErrInvalidCursor, func(ctx context.Context, c clients) error {
+			_, err := c.users.ListReviews(ctx, &reviewerv1.ListReviewsRequest{UserId: "u1", Cursor: "x"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClients(t, &backend{err: tt.err})
+			checkCode(t, tt.call(as(t, tt.token), c), tt.code)
+		})
+	}
+}
+
+func TestPullRequestRPCs(t *testing.T) {
+	create := func(author string, lines int32) func(context.Context, clients) error {
+		return func(ctx context.Context, c clients) error {
+			pr, err := c.prs.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+				PullRequestId: "pr1", PullRequestName: "fix", AuthorId: author, LinesChanged: lines,
+			})
+			if err == nil && (pr.GetPullRequestId() != "pr1" || len(pr.GetReviewers()) != 2) {
+				return fmt.Errorf("unexpected pull request %v", pr)
+			}
+			return err
+		}
+	}
+	merge := func(id string) func(context.Context, clients) error {
+		return func(ctx context.Context, c clients) error {
+			pr, err := c.prs.Merge(ctx, &reviewerv1.MergeRequest{PullRequestId: id})
+			if err == nil && pr.GetStatus() != string(entities.PRStatusMerged) {
+				return fmt.Errorf("unexpected status %q", pr.GetStatus())
+			}
+			return err
+		}
+	}
+	approve := func(ctx context.Context, c clients) error {
+		version := int64(3)
+		_, err := c.prs.Approve(ctx, &reviewerv1.ApproveRequest{PullRequestId: "pr1", ExpectedVersion: &version})
+		return err
+	}
+	tests := []struct {
+		name  string
+		token string
+		err   error
+		call  func(context.Context, clients) error
+		code  codes.Code
+	}{
+		{"author creates a PR", "member", nil, create("u1", 10), codes.OK},
+		{"author defaults to the caller", "member", nil, create("", 10), codes.OK},
+		{"service token must name the author", "service", nil, create("", 10), codes.InvalidArgument},
+		{"member cannot create for someone else", "member", nil, create("u2", 10), codes.PermissionDenied},
+		{"team admin creates for a member", "lead", nil, create("u1", 10), codes.OK},
+		{"negative size", "member", nil, create("u1", -1), codes.InvalidArgument},
+		{"duplicate PR", "member", errors.ErrPRExists, create("u1", 10), codes.AlreadyExists},
+		{"unknown author", "admin", errors.ErrUserNotFound, create("ghost", 10), codes.NotFound},
+		{"reviewers at capacity", "member", errors.ErrReviewersAtCapacity, create("u1", 10), codes.ResourceExhausted},
+		{"no candidates", "member", errors.ErrNoCandidates, create("u1", 10), codes.FailedPrecondition},
+		{"author merges", "member", nil, merge("pr1"), codes.OK},
+		{"others cannot merge", "other", nil, merge("pr1"), codes.PermissionDenied},
+		{"missing PR", "member", nil, merge("ghost"), codes.NotFound},
+		{"lead approval missing", "admin", errors.ErrLeadApprovalRequired, merge("pr1"), codes.FailedPrecondition},
+		{"reviewer approves", "other", nil, approve, codes.OK},
+		{"approve needs a user", "service", nil, approve, codes.Unauthenticated},
+		{"stale version", "other", errors.ErrVersionMismatch, approve, codes.Aborted},
+		{"not a reviewer", "other", errors.ErrNoSuchReviewer, approve, codes.FailedPrecondition},
+		{"merged PR", "other", errors.ErrPRAlreadyMerged, approve, codes.FailedPrecondition},
+		{"reviewer reassigns themself", "other", nil, func(ctx context.Context, c clients) error {
+			resp, err := c.prs.Reassign(ctx, &reviewerv1.ReassignRequest{PullRequestId: "pr1", OldUserId: "u9"})
+			if err == nil && resp.GetReplacedBy() != "u3" {
+				return fmt.Errorf("replaced by %q", resp.GetReplacedBy())
+			}
+			return err
+		}, codes.OK},
+		{"decline needs a reason", "other", nil, func(ctx context.Context, c clients) error {
+			_, err := c.prs.Decline(ctx, &reviewerv1.DeclineRequest{PullRequestId: "pr1"})
+			return err
+		}, codes.InvalidArgument},
+		{"bad status filter", "member", nil, func(ctx context.Context, c clients) error {
+			_, err := c.prs.SearchPullRequests(ctx, &reviewerv1.SearchPullRequestsRequest{Status: "DRAFT"})
+			return err
+		}, codes.InvalidArgument},
+		{"explain assignment", "member", nil, func(ctx context.Context, c clients) error {
+			resp, err := c.prs.ExplainAssignment(ctx, &reviewerv1.ExplainAssignmentRequest{PullRequestId: "pr1"})
+			if err == nil && resp.GetDecisions()[0].GetExcluded()[0].GetReason() != string(entities.ExclusionNoMentor) {
+				return fmt.Errorf("unexpected decisions %v", resp.GetDecisions())
+			}
+			return err
+		}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClients(t, &backend{err: tt.err})
+			checkCode(t, tt.call(as(t, tt.token), c), tt.code)
+		})
+	}
+}
+
+type fakeAuth struct{}
+
+// principals are the callers the test tokens stand for.
+var principals = map[string]entities.Principal{
+	"admin":         {OrgID: "acme", UserID: "root", Role: entities.RoleAdmin},
+	"lead":          {OrgID: "acme", UserID: "u5", Role: entities.RoleTeamAdmin, Teams: []string{"backend"}},
+	"frontend-lead": {OrgID: "acme", UserID: "u6", Role: entities.RoleTeamAdmin, Teams: []string{"frontend"}},
+	"member":        {OrgID: "acme", UserID: "u1", Role: entities.RoleMember},
+	"other":         {OrgID: "acme", UserID: "u9", Role: entities.RoleMember},
+	"service":       {OrgID: "acme", Role: entities.RoleMember},
+}
+
+func (fakeAuth) Authenticate(_ context.Context, credential string) (*entities.Principal, error) {
+	p, ok := principals[credential]
+	if !ok {
+		return nil, errors.ErrUnauthenticated
+	}
+	return &p, nil
+}
+
+// users are the users the fake user service knows, by id.
+var users = map[string]entities.User{
+	"u1": {UserID: "u1", Username: "alice", IsActive: true, TeamName: "backend"},
+	"u2": {UserID: "u2", Username: "bob", IsActive: true, TeamName: "frontend"},
+}
+
+type fakeUsers struct{ *backend }
+
+func (f fakeUsers) user(id string) (*entities.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	u := users[id]
+	return &u, nil
+}
+
+func (f fakeUsers) SetIsActive(_ context.Context, id string, _ bool) (*entities.User, error) {
+	return f.user(id)
+}
+
+func (f fakeUsers) SetSeniority(_ context.Context, id string, _ entities.Seniority) (*entities.User, error) {
+	return f.user(id)
+}
+
+func (f fakeUsers) SetMaxOpenReviews(_ context.Context, id string, _ *int) (*entities.User, error) {
+	return f.user(id)
+}
+
+func (fakeUsers) GetByID(_ context.Context, id string) (*entities.User, error) {
+	u, ok := users[id]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (f fakeUsers) ListByTeam(context.Context, string) ([]entities.User, error) {
+	return nil, f.err
+}
+
+func (f fakeUsers) ListActiveByTeam(context.Context, string) ([]entities.User, error) {
+	return nil, f.err
+}
+
+type fakeTeams struct{ *backend }
+
+func (f fakeTeams) team(name string) (*entities.Team, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entities.Team{
+		TeamName: name,
+		Settings: entities.TeamSettings{ReviewersCount: entities.DefaultReviewersCount},
+		Members:  []entities.TeamMember{{User: users["u1"], IsPrimary: true, ReviewWeight: 1, Role: entities.MemberRoleMember}},
+	}, nil
+}
+
+func (f fakeTeams) CreateTeam(_ context.Context, team *entities.Team, _ bool) (*entities.Team, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return team, nil
+}
+
+func (f fakeTeams) GetTeam(_ context.Context, name string) (*entities.Team, error) {
+	return f.team(name)
+}
+
+func (f fakeTeams) UpdateTeam(_ context.Context, name string, upd entities.TeamUpdate) (*entities.Team, error) {
+	team, err := f.team(name)
+	if err == nil && upd.Settings != nil {
+		team.Settings = *upd.Settings
+	}
+	return team, err
+}
+
+func (f fakeTeams) RenameTeam(_ context.Context, _, newName string) (*entities.Team, error) {
+	return f.team(newName)
+}
+
+func (f fakeTeams) DeleteTeam(context.Context, string, string) error { return f.err }
+
+func (f fakeTeams) TeamTree(context.Context, string) ([]entities.TeamNode, error) {
+	return []entities.TeamNode{{TeamName: "backend"}}, f.err
+}
+
+func (f fakeTeams) GetStats(_ context.Context, name string) (*entities.TeamStats, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entities.TeamStats{TeamName: name}, nil
+}
+
+type fakePRs struct{ *backend }
+
+// pr returns pr1, authored by u1 and reviewed by u9 and u2. Any other id is
+// unknown.
+func (f fakePRs) pr(id string) (*entities.PullRequest, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if id != "pr1" {
+		return nil, errors.ErrPRNotFound
+	}
+	return &entities.PullRequest{
+		PRID:      id,
+		Name:      "fix",
+		AuthorID:  "u1",
+		Status:    entities.PRStatusOpen,
+		Reviewers: []string{"u9", "u2"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:   3,
+	}, nil
+}
+
+func (f fakePRs) CreatePR(ctx context.Context, id, _, authorID string, _ entities.PRSize) (*entities.PullRequest, error) {
+	pr, err := f.pr(id)
+	if err == nil && authorID == "" {
+		// The real service falls back to the caller, which only works for
+		// callers that are users.
+		pr.AuthorID = services.ActorFromContext(ctx)
+	}
+	return pr, err
+}
+
+func (f fakePRs) PreviewAssignment(context.Context, string, string, entities.PRSize) (*entities.AssignmentPreview, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entities.AssignmentPreview{}, nil
+}
+
+func (f fakePRs) ReplaceReviewer(_ context.Context, id, _ string) (*entities.PullRequest, string, error) {
+	pr, err := f.pr(id)
+	return pr, "u3", err
+}
+
+func (f fakePRs) Decline(_ context.Context, id, _, _ string) (*entities.PullRequest, string, error) {
+	pr, err := f.pr(id)
+	return pr, "u3", err
+}
+
+func (f fakePRs) Approve(_ context.Context, id, reviewerID string) (*entities.PullRequest, error) {
+	pr, err := f.pr(id)
+	if err == nil {
+		pr.Approvals = []string{reviewerID}
+	}
+	return pr, err
+}
+
+func (f fakePRs) Merge(_ context.Context, id string) (*entities.PullRequest, error) {
+	pr, err := f.pr(id)
+	if err == nil {
+		pr.Status = entities.PRStatusMerged
+	}
+	return pr, err
+}
+
+func (f fakePRs) Close(_ context.Context, id string) (*entities.PullRequest, error) {
+	return f.pr(id)
+}
+
+func (f fakePRs) History(context.Context, string) ([]entities.PREvent, error) {
+	return nil, f.err
+}
+
+func (f fakePRs) EventsSince(context.Context, entities.PREventFilter) (*entities.PREventPage, error) {
+	return &entities.PREventPage{}, f.err
+}
+
+func (f fakePRs) LastEventID(context.Context) (int64, error) { return 0, f.err }
+
+func (f fakePRs) ExplainAssignment(_ context.Context, id, _ string) ([]entities.AssignmentDecision, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []entities.AssignmentDecision{{
+		PRID:       id,
+		ReviewerID: "u9",
+		Slot:       entities.ReviewerSlotRegular,
+		Excluded:   []entities.ExcludedCandidate{{UserID: "u4", Reason: entities.ExclusionNoMentor}},
+	}}, nil
+}
+
+// GetPR backs the access checks as well, so it ignores the backend error.
+func (fakePRs) GetPR(_ context.Context, id string) (*entities.PullRequest, error) {
+	return fakePRs{&backend{}}.pr(id)
+}
+
+func (f fakePRs) Search(context.Context, entities.PRSearchFilter, string) (*entities.PRPage, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entities.PRPage{}, nil
+}
+
+func (f fakePRs) ListByReviewer(context.Context, entities.ReviewListFilter, string) (*entities.ReviewPage, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	pr, _ := fakePRs{&backend{}}.pr("pr1")
+	return &entities.ReviewPage{
+		PRPage: entities.PRPage{PullRequests: []entities.PullRequest{*pr}},
+		Counts: entities.ReviewCounts{Total: 1, Open: 1},
+	}, nil
+}
@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"context"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type teamServer struct {
+	reviewerv1.UnimplementedTeamServiceServer
+	*Server
+}
+
+func toMember(m *reviewerv1.NewTeamMember) (entities.TeamMember, error) {
+	if err := required("user_id", m.GetUserId(), "username", m.GetUsername()); err != nil {
+		return entities.TeamMember{}, err
+	}
+	if err := oneOf("seniority", m.GetSeniority(), "junior", "middle", "senior"); err != nil {
+		return entities.TeamMember{}, err
+	}
+	if err := oneOf("role", m.GetRole(), "member", "lead"); err != nil {
+		return entities.TeamMember{}, err
+	}
+	member := entities.TeamMember{
+		User: entities.User{
+			UserID:    m.GetUserId(),
+			Username:  m.GetUsername(),
+			IsActive:  m.GetIsActive(),
+			Seniority: entities.Seniority(m.GetSeniority()),
+		},
+		ReviewWeight: 1,
+		Role:         entities.MemberRoleMember,
+	}
+	if m.ReviewWeight != nil {
+		if *m.ReviewWeight < 0 {
+			return entities.TeamMember{}, invalidArgument("review_weight must not be negative")
+		}
+		member.ReviewWeight = *m.ReviewWeight
+	}
+	if m.GetRole() != "" {
+		member.Role = entities.MemberRole(m.GetRole())
+	}
+	return member, nil
+}
+
+// toSettings mirrors TeamSettingsRequest of the HTTP API: omitted strategy
+// fields fall back to random selection with the default window and decay.
+func toSettings(s *reviewerv1.TeamSettings) (entities.TeamSettings, error) {
+	switch {
+	case s.GetReviewersCount() < 1:
+		return entities.TeamSettings{}, invalidArgument("reviewers_count must be at least 1")
+	case s.GetLeadReviewMinLines() < 0, s.GetLeadReviewMinFiles() < 0, s.GetMaxOpenReviews() < 0:
+		return entities.TeamSettings{}, invalidArgument("thresholds and limits must not be negative")
+	case s.GetPairingWindowDays() < 0:
+		return entities.TeamSettings{}, invalidArgument("pairing_window_days must be positive")
+	case s.GetPairingDecay() < 0 || s.GetPairingDecay() > 1:
+		return entities.TeamSettings{}, invalidArgument("pairing_decay must be in (0, 1]")
+	}
+	if err := oneOf("strategy", s.GetStrategy(), "random", "spread_knowledge"); err != nil {
+		return entities.TeamSettings{}, err
+	}
+	if err := oneOf("overload_policy", s.GetOverloadPolicy(), "assign", "queue", "fail"); err != nil {
+		return entities.TeamSettings{}, err
+	}
+
+	settings := entities.TeamSettings{
+		ReviewersCount:     int(s.GetReviewersCount()),
+		EscalateToParent:   s.GetEscalateToParent(),
+		LeadReviewMinLines: int(s.GetLeadReviewMinLines()),
+		LeadReviewMinFiles: int(s.GetLeadReviewMinFiles()),
+		MentorshipRequired: s.GetMentorshipRequired(),
+		ShadowJuniors:      s.GetShadowJuniors(),
+		Strategy:           entities.StrategyRandom,
+		PairingWindowDays:  entities.DefaultPairingWindowDays,
+		PairingDecay:       entities.DefaultPairingDecay,
+		MaxOpenReviews:     int(s.GetMaxOpenReviews()),
+		OverloadPolicy:     entities.OverloadAssign,
+	}
+	if s.GetStrategy() != "" {
+		settings.Strategy = entities.SelectionStrategy(s.GetStrategy())
+	}
+	if s.GetPairingWindowDays() != 0 {
+		settings.PairingWindowDays = int(s.GetPairingWindowDays())
+	}
+	if s.GetPairingDecay() != 0 {
+		settings.PairingDecay = s.GetPairingDecay()
+	}
+	if s.GetOverloadPolicy() != "" {
+		settings.OverloadPolicy = entities.OverloadPolicy(s.GetOverloadPolicy())
+	}
+	return settings, nil
+}
+
+func (s *teamServer) AddTeam(ctx context.Context, req *reviewerv1.AddTeamRequest) (*reviewerv1.Team, error) {
+	if err := required("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	team := &entities.Team{TeamName: req.GetTeamName(), ParentTeam: req.GetParentTeam()}
+	for _, m := range req.GetMembers() {
+		member, err := toMember(m)
+		if err != nil {
+			return nil, err
+		}
+		member.TeamName = req.GetTeamName()
+		member.IsPrimary = true
+		team.Members = append(team.Members, member)
+	}
+
+	created, err := s.teams.CreateTeam(ctx, team, req.GetMoveExistingMembers())
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(created), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.Team, error) {
+	if err := required("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	t, err := s.teams.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(t), nil
+}
+
+func (s *teamServer) UpdateTeam(ctx context.Context, req *reviewerv1.UpdateTeamRequest) (*reviewerv1.Team, error) {
+	if err := required("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	teams := []string{req.GetTeamName()}
+	if req.GetParentTeam() != "" {
+		teams = append(teams, req.GetParentTeam())
+	}
+	if err := requireTeams(ctx, teams...); err != nil {
+		return nil, err
+	}
+
+	upd := entities.TeamUpdate{
+		RemoveMembers:       req.GetRemoveMembers(),
+		ParentTeam:          req.ParentTeam,
+		MoveExistingMembers: req.GetMoveExistingMembers(),
+	}
+	for _, m := range req.GetAddMembers() {
+		if err := s.requireNewOrManagedUser(ctx, m.GetUserId()); err != nil {
+			return nil, err
+		}
+		member, err := toMember(m)
+		if err != nil {
+			return nil, err
+		}
+		member.IsPrimary = m.GetIsPrimary()
+		upd.AddMembers = append(upd.AddMembers, member)
+	}
+	if req.Settings != nil {
+		settings, err := toSettings(req.Settings)
+		if err != nil {
+			return nil, err
+		}
+		upd.Settings = &settings
+	}
+
+	team, err := s.teams.UpdateTeam(ctx, req.GetTeamName(), upd)
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
+
+func (s *teamServer) RenameTeam(ctx context.Context, req *reviewerv1.RenameTeamRequest) (*reviewerv1.Team, error) {
+	if err := required("team_name", req.GetTeamName(), "new_name", req.GetNewName()); err != nil {
+		return nil, err
+	}
+	if err := requireTeams(ctx, req.GetTeamName()); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teams.RenameTeam(ctx, req.GetTeamName(), req.GetNewName())
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
+
+func (s *teamServer) DeleteTeam(ctx context.Context, req *reviewerv1.DeleteTeamRequest) (*reviewerv1.DeleteTeamResponse, error) {
+	if err := required("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	teams := []string{req.GetTeamName()}
+	if req.GetTargetTeam() != "" {
+		teams = append(teams, req.GetTargetTeam())
+	}
+	if err := requireTeams(ctx, teams...); err != nil {
+		return nil, err
+	}
+
+	if err := s.teams.DeleteTeam(ctx, req.GetTeamName(), req.GetTargetTeam()); err != nil {
+		return nil, err
+	}
+	return &reviewerv1.DeleteTeamResponse{}, nil
+}
+
+func (s *teamServer) GetTeamTree(ctx context.Context, req *reviewerv1.GetTeamTreeRequest) (*reviewerv1.GetTeamTreeResponse, error) {
+	tree, err := s.teams.TeamTree(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.GetTeamTreeResponse{Teams: toTeamNodes(tree)}, nil
+}
+
+func (s *teamServer) GetTeamStats(ctx context.Context, req *reviewerv1.GetTeamStatsRequest) (*reviewerv1.TeamStats, error) {
+	if err := required("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	stats, err := s.teams.GetStats(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return toTeamStats(stats), nil
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+
+	reviewerv1 "github.com/f4ke-n0name/avito/api/proto/reviewer/v1"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type userServer struct {
+	reviewerv1.UnimplementedUserServiceServer
+	*Server
+}
+
+func (s *userServer) SetIsActive(ctx context.Context, req *reviewerv1.SetIsActiveRequest) (*reviewerv1.User, error) {
+	if err := required("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.requireUserAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, err
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) SetSeniority(ctx context.Context, req *reviewerv1.SetSeniorityRequest) (*reviewerv1.User, error) {
+	if err := required("user_id", req.GetUserId(), "seniority", req.GetSeniority()); err != nil {
+		return nil, err
+	}
+	if err := oneOf("seniority", req.GetSeniority(), "junior", "middle", "senior"); err != nil {
+		return nil, err
+	}
+	if err := s.requireUserAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.SetSeniority(ctx, req.GetUserId(), entities.Seniority(req.GetSeniority()))
+	if err != nil {
+		return nil, err
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) SetMaxOpenReviews(ctx context.Context, req *reviewerv1.SetMaxOpenReviewsRequest) (*reviewerv1.User, error) {
+	if err := required("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if req.MaxOpenReviews != nil && *req.MaxOpenReviews < 1 {
+		return nil, invalidArgument("max_open_reviews must be at least 1")
+	}
+	if err := s.requireUserAdmin(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.SetMaxOpenReviews(ctx, req.GetUserId(), intPtr(req.MaxOpenReviews))
+	if err != nil {
+		return nil, err
+	}
+	return toUser(u), nil
+}
+
+func (s *userServer) ListReviews(ctx context.Context, req *reviewerv1.ListReviewsRequest) (*reviewerv1.ListReviewsResponse, error) {
+	if err := required("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if err := oneOf("status", req.GetStatus(), "OPEN", "MERGED", "CLOSED"); err != nil {
+		return nil, err
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > 100 {
+		return nil, invalidArgument("limit must be between 1 and 100")
+	}
+
+	page, err := s.pr.ListByReviewer(ctx, entities.ReviewListFilter{
+		ReviewerID:  req.GetUserId(),
+		Status:      entities.PRStatus(req.GetStatus()),
+		OnlyPending: req.GetOnlyPending(),
+		Limit:       int(req.GetLimit()),
+	}, req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewerv1.ListReviewsResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: toPullRequests(page.PullRequests),
+		NextCursor:   page.NextCursor,
+		Counts: &reviewerv1.ReviewCounts{
+			Total:    int32(page.Counts.Total),
+			Open:     int32(page.Counts.Open),
+			Merged:   int32(page.Counts.Merged),
+			Closed:   int32(page.Counts.Closed),
+			Approved: int32(page.Counts.Approved),
+			Declined: int32(page.Counts.Declined),
+		},
+	}, nil
+}
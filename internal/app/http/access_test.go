@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+// TestUpdateTeamAccess adds members to backend as different callers. Users
+// that already exist may only be added by administrators of their primary
+// team, so that updating one team cannot take users from another.
+func TestUpdateTeamAccess(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		member string
+		status int
+	}{
+		{"admin adds a user of another team", "admin", "u-frontend", http.StatusOK},
+		{"team admin adds a new user", "backend-admin", "u-new", http.StatusOK},
+		{"team admin adds a user of the team", "backend-admin", "u-backend", http.StatusOK},
+		{"team admin adds a user of another team", "backend-admin", "u-frontend", http.StatusForbidden},
+		{"admin of another team", "frontend-admin", "u-new", http.StatusForbidden},
+		{"member", "member", "u-new", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams := &recordingTeams{}
+			engine := newAccessEngine(t, teams)
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, apiPrefix+"/team/update", strings.NewReader(
+				`{"team_name":"backend","add_members":[{"user_id":"`+tt.member+`","username":"Eve","is_active":false}]}`))
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			engine.ServeHTTP(rec, r)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if updated := teams.updated != ""; updated != (tt.status == http.StatusOK) {
+				t.Errorf("team updated = %v with status %d", updated, rec.Code)
+			}
+		})
+	}
+}
+
+// TestReparentTeamAccess moves backend under another team. A team admin needs
+// to administer the new parent too; detaching needs only the team itself.
+func TestReparentTeamAccess(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		parent string
+		status int
+	}{
+		{"admin", "admin", "frontend", http.StatusOK},
+		{"team admin of both teams", "platform-admin", "frontend", http.StatusOK},
+		{"team admin of the team only", "backend-admin", "frontend", http.StatusForbidden},
+		{"team admin detaches the team", "backend-admin", "", http.StatusOK},
+		{"team admin of the parent only", "frontend-admin", "frontend", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams := &recordingTeams{}
+			engine := newAccessEngine(t, teams)
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, apiPrefix+"/team/update", strings.NewReader(
+				`{"team_name":"backend","parent_team":"`+tt.parent+`"}`))
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			engine.ServeHTTP(rec, r)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if updated := teams.updated != ""; updated != (tt.status == http.StatusOK) {
+				t.Errorf("team updated = %v with status %d", updated, rec.Code)
+			}
+		})
+	}
+}
+
+func newAccessEngine(t *testing.T, teams interfaces.TeamService) *gin.Engine {
+	return newEngineWith(t, testServer{users: accessUsers{}, teams: teams, authn: tokenAuth{}})
+}
+
+// tokenAuth authenticates the tokens of the access tests.
+type tokenAuth struct{}
+
+func (tokenAuth) Authenticate(_ context.Context, token string) (*entities.Principal, error) {
+	p, ok := map[string]entities.Principal{
+		"admin":          {OrgID: "acme", UserID: "root", Role: entities.RoleAdmin},
+		"backend-admin":  {OrgID: "acme", UserID: "u-backend", Role: entities.RoleTeamAdmin, Teams: []string{"backend"}},
+		"frontend-admin": {OrgID: "acme", UserID: "u-frontend", Role: entities.RoleTeamAdmin, Teams: []string{"frontend"}},
+		"platform-admin": {OrgID: "acme", UserID: "u-backend", Role: entities.RoleTeamAdmin, Teams: []string{"backend", "frontend"}},
+		"member":         {OrgID: "acme", UserID: "u-backend", Role: entities.RoleMember},
+	}[token]
+	if !ok {
+		return nil, errors.ErrUnauthenticated
+	}
+	return &p, nil
+}
+
+// accessUsers knows one user of backend and one of frontend.
+type accessUsers struct{ stubUsers }
+
+func (accessUsers) GetByID(_ context.Context, id string) (*entities.User, error) {
+	team, ok := map[string]string{"u-backend": "backend", "u-frontend": "frontend"}[id]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	u := sampleUser(id)
+	u.TeamName = team
+	return u, nil
+}
+
+// recordingTeams remembers the team UpdateTeam was called for.
+type recordingTeams struct {
+	stubTeams
+	updated string
+}
+
+func (r *recordingTeams) UpdateTeam(ctx context.Context, name string, upd entities.TeamUpdate) (*entities.Team, error) {
+	r.updated = name
+	return r.stubTeams.UpdateTeam(ctx, name, upd)
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+// authenticate resolves the bearer token into a principal on the request
+// context. Requests without a valid token are rejected.
+func authenticate(authn interfaces.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			respondProblem(c, http.StatusUnauthorized, "UNAUTHORIZED", "bearer token is required")
+			return
+		}
+		p, err := authn.Authenticate(c, strings.TrimSpace(secret))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.Request = c.Request.WithContext(services.WithPrincipal(c.Request.Context(), *p))
+		c.Next()
+	}
+}
+
+// principal returns the caller attached by authenticate.
+func principal(c *gin.Context) entities.Principal {
+	p, _ := services.PrincipalFromContext(c)
+	return p
+}
+
+// managesUser reports whether the caller administers the user's primary team.
+func (s *Server) managesUser(c *gin.Context, userID string) bool {
+	p := principal(c)
+	if p.IsAdmin() {
+		return true
+	}
+	if p.Role != entities.RoleTeamAdmin {
+		return false
+	}
+	u, err := s.users.GetByID(c, userID)
+	return err == nil && u != nil && p.ManagesTeam(u.TeamName)
+}
+
+// requireUserAdmin lets through callers that administer the user's primary
+// team. Otherwise it responds with 403, or with 404 to a team administrator
+// naming an unknown user, and returns false.
+func (s *Server) requireUserAdmin(c *gin.Context, userID string) bool {
+	p := principal(c)
+	if p.IsAdmin() {
+		return true
+	}
+	if p.Role != entities.RoleTeamAdmin {
+		forbidden(c)
+		return false
+	}
+	u, err := s.users.GetByID(c, userID)
+	if err == nil && u == nil {
+		err = errors.ErrUserNotFound
+	}
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if !p.ManagesTeam(u.TeamName) {
+		forbidden(c)
+		return false
+	}
+	return true
+}
+
+// requireNewOrManagedUser lets through users that do not exist yet and users
+// whose primary team the caller administers. Otherwise it responds with 403
+// and returns false.
+func (s *Server) requireNewOrManagedUser(c *gin.Context, userID string) bool {
+	p := principal(c)
+	if p.IsAdmin() {
+		return true
+	}
+	u, err := s.users.GetByID(c, userID)
+	if stderrors.Is(err, errors.ErrUserNotFound) {
+		return true
+	}
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if u != nil && !p.ManagesTeam(u.TeamName) {
+		forbidden(c)
+		return false
+	}
+	return true
+}
+
+// actsFor reports whether the caller may act on behalf of the user: as the
+// user, or as an administrator of the user's primary team.
+func (s *Server) actsFor(c *gin.Context, userID string) bool {
+	if userID == "" {
+		return false
+	}
+	return principal(c).UserID == userID || s.managesUser(c, userID)
+}
+
+// authorizePR lets admins, the PR author and the given extra users through.
+// Otherwise it responds with 404 or 403 and returns false.
+func (s *Server) authorizePR(c *gin.Context, prID string, extra ...string) bool {
+	p := principal(c)
+	if p.IsAdmin() {
+		return true
+	}
+	pr, err := s.pr.GetPR(c, prID)
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if p.UserID != "" && (p.UserID == pr.AuthorID || containsString(extra, p.UserID)) {
+		return true
+	}
+	forbidden(c)
+	return false
+}
+
+func containsString(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// requireAdmin is middleware for admin-only routes.
+func requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !principal(c).IsAdmin() {
+			respondProblem(c, http.StatusForbidden, "FORBIDDEN", "admin role is required")
+			return
+		}
+		c.Next()
+	}
+}
+
+type TokenCreateRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	UserID    string     `json:"user_id"`
+	Role      string     `json:"role" binding:"required,oneof=admin team-admin member"`
+	Teams     []string   `json:"teams"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (s *Server) createToken(c *gin.Context) {
+	var req TokenCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if req.Role == string(entities.RoleTeamAdmin) && len(req.Teams) == 0 {
+		respondProblem(c, http.StatusBadRequest, "INVALID_SCOPE", "team-admin tokens need at least one team")
+		return
+	}
+
+	t := &entities.APIToken{
+		Name:      req.Name,
+		UserID:    req.UserID,
+		Role:      entities.Role(req.Role),
+		Teams:     req.Teams,
+		ExpiresAt: req.ExpiresAt,
+	}
+	secret, err := s.auth.CreateToken(c, t)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"token": t, "secret": secret})
+}
+
+func (s *Server) listTokens(c *gin.Context) {
+	tokens, err := s.auth.ListTokens(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+func (s *Server) revokeToken(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("token_id"), 10, 64)
+	if err != nil {
+		invalidParam(c, "token_id", "must be an integer")
+		return
+	}
+	if err := s.auth.RevokeToken(c, id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
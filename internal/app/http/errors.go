@@ -0,0 +1,220 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+const (
+	correlationHeader = "X-Request-ID"
+	correlationKey    = "correlation_id"
+	legacyKey         = "legacy_route"
+)
+
+// Problem is an RFC 7807 problem details document. Code is the stable,
+// machine-readable error code clients should switch on.
+type Problem struct {
+	Type          string           `json:"type"`
+	Title         string           `json:"title"`
+	Status        int              `json:"status"`
+	Code          string           `json:"code"`
+	Detail        string           `json:"detail,omitempty"`
+	Instance      string           `json:"instance,omitempty"`
+	CorrelationID string           `json:"correlation_id,omitempty"`
+	InvalidParams []InvalidParam   `json:"invalid_params,omitempty"`
+	Conflicts     []MemberConflict `json:"conflicts,omitempty"`
+}
+
+// InvalidParam names a request field that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type MemberConflict struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+// domainErrors maps domain errors to responses. Entries are matched with
+// errors.Is in order; the error's own message becomes the detail.
+var domainErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{errors.ErrPRNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{errors.ErrUserNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{errors.ErrTeamNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{errors.ErrParentNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{errors.ErrTokenNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{errors.ErrPRExists, http.StatusConflict, "PR_EXISTS"},
+	{errors.ErrTeamExists, http.StatusConflict, "TEAM_EXISTS"},
+	{errors.ErrMemberConflict, http.StatusConflict, "MEMBER_CONFLICT"},
+	{errors.ErrTeamCycle, http.StatusConflict, "TEAM_CYCLE"},
+	{errors.ErrTeamHasOpenPRs, http.StatusConflict, "TEAM_HAS_OPEN_PRS"},
+	{errors.ErrPRAlreadyMerged, http.StatusConflict, "PR_MERGED"},
+	{errors.ErrPRClosed, http.StatusConflict, "PR_CLOSED"},
+	{errors.ErrNoSuchReviewer, http.StatusConflict, "NOT_ASSIGNED"},
+	{errors.ErrReviewerNotInTeam, http.StatusConflict, "NOT_IN_TEAM"},
+	{errors.ErrReviewerInactive, http.StatusConflict, "REVIEWER_INACTIVE"},
+	{errors.ErrNoCandidates, http.StatusConflict, "NO_CANDIDATE"},
+	{errors.ErrNoLeadCandidate, http.StatusConflict, "NO_LEAD"},
+	{errors.ErrLeadApprovalRequired, http.StatusConflict, "LEAD_APPROVAL_REQUIRED"},
+	{errors.ErrReviewersAtCapacity, http.StatusConflict, "AT_CAPACITY"},
+	{errors.ErrVersionMismatch, http.StatusPreconditionFailed, "VERSION_MISMATCH"},
+	{errors.ErrIdempotencyKeyInUse, http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE"},
+	{errors.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED"},
+	{errors.ErrInvalidTargetTeam, http.StatusBadRequest, "INVALID_TARGET"},
+	{errors.ErrInvalidCursor, http.StatusBadRequest, "INVALID_CURSOR"},
+	{errors.ErrUnauthenticated, http.StatusUnauthorized, "UNAUTHORIZED"},
+	{errors.ErrForbidden, http.StatusForbidden, "FORBIDDEN"},
+}
+
+func init() {
+	// Report validation failures under the names clients send, not the Go
+	// field names.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" && name != "-" {
+					return name
+				}
+			}
+			return f.Name
+		})
+	}
+}
+
+// correlate assigns every request a correlation id, taken from the
+// X-Request-ID header when the client sends one, and echoes it back.
+func correlate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(correlationHeader)
+		if id == "" || len(id) > 64 {
+			buf := make([]byte, 8)
+			_, _ = rand.Read(buf)
+			id = hex.EncodeToString(buf)
+		}
+		c.Set(correlationKey, id)
+		c.Header(correlationHeader, id)
+		c.Next()
+	}
+}
+
+// deprecated marks the unversioned routes, which remain as aliases of /api/v1
+// and keep the original error envelope.
+func deprecated() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(legacyKey, true)
+		c.Header("Deprecation", "true")
+		if path := c.FullPath(); path != "" {
+			c.Header("Link", "<"+apiPrefix+path+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// respondError writes the response for an error returned by a service.
+// Errors that are not domain errors are logged and answered with a generic
+// 500 that only carries the correlation id.
+func respondError(c *gin.Context, err error) {
+	for _, e := range domainErrors {
+		if !stderrors.Is(err, e.err) {
+			continue
+		}
+		p := newProblem(c, e.status, e.code, e.err.Error())
+		var conflict *errors.MemberConflictError
+		if stderrors.As(err, &conflict) {
+			p.Detail = "members already belong to another team, set move_existing_members to move them"
+			for _, mc := range conflict.Conflicts {
+				p.Conflicts = append(p.Conflicts, MemberConflict{UserID: mc.UserID, TeamName: mc.TeamName})
+			}
+		}
+		render(c, p)
+		return
+	}
+
+	p := newProblem(c, http.StatusInternalServerError, "INTERNAL", "")
+	log.Printf("request %s %s %s failed: %v", p.CorrelationID, c.Request.Method, c.Request.URL.Path, err)
+	p.Detail = "internal error, quote correlation id " + p.CorrelationID + " when reporting it"
+	render(c, p)
+}
+
+// respondProblem writes an error detected by the handler itself.
+func respondProblem(c *gin.Context, status int, code, detail string) {
+	render(c, newProblem(c, status, code, detail))
+}
+
+// badRequest answers a request that failed to bind. Only field names and the
+// failed rules are reported, never the raw decoder error.
+func badRequest(c *gin.Context, err error) {
+	p := newProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "request is malformed")
+	var verrs validator.ValidationErrors
+	if stderrors.As(err, &verrs) {
+		p.Detail = "request failed validation"
+		for _, fe := range verrs {
+			reason := fe.Tag()
+			if fe.Param() != "" {
+				reason += "=" + fe.Param()
+			}
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: fe.Field(), Reason: reason})
+		}
+	}
+	render(c, p)
+}
+
+// invalidParam answers a request with a single invalid parameter.
+func invalidParam(c *gin.Context, name, reason string) {
+	p := newProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "request failed validation")
+	p.InvalidParams = []InvalidParam{{Name: name, Reason: reason}}
+	render(c, p)
+}
+
+func forbidden(c *gin.Context) {
+	respondProblem(c, http.StatusForbidden, "FORBIDDEN", "not allowed for this caller")
+}
+
+func newProblem(c *gin.Context, status int, code, detail string) *Problem {
+	return &Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Code:          code,
+		Detail:        detail,
+		Instance:      c.Request.URL.Path,
+		CorrelationID: c.GetString(correlationKey),
+	}
+}
+
+// render aborts the request with the problem, in the legacy envelope on
+// deprecated routes.
+func render(c *gin.Context, p *Problem) {
+	if c.GetBool(legacyKey) {
+		body := gin.H{"code": p.Code, "message": p.Detail}
+		if p.CorrelationID != "" {
+			body["correlation_id"] = p.CorrelationID
+		}
+		if len(p.InvalidParams) > 0 {
+			body["invalid_params"] = p.InvalidParams
+		}
+		if len(p.Conflicts) > 0 {
+			body["conflicts"] = p.Conflicts
+		}
+		c.AbortWithStatusJSON(p.Status, gin.H{"error": body})
+		return
+	}
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(p.Status, p)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+)
+
+// etag is the entity tag of a PR: its version as a strong validator.
+func etag(pr *entities.PullRequest) string {
+	return strconv.Quote(strconv.FormatInt(pr.Version, 10))
+}
+
+// respondPR writes a response about a single PR together with its ETag.
+func respondPR(c *gin.Context, status int, pr *entities.PullRequest, body gin.H) {
+	c.Header("ETag", etag(pr))
+	c.JSON(status, body)
+}
+
+// ifMatch turns an If-Match header into the version a PR mutation expects.
+// "*" matches any existing PR; a tag that is not a version this service
+// issued never matches.
+func ifMatch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tag := strings.TrimSpace(c.GetHeader("If-Match"))
+		if tag == "" || tag == "*" {
+			c.Next()
+			return
+		}
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			unquoted = ""
+		}
+		version, err := strconv.ParseInt(unquoted, 10, 64)
+		if err != nil {
+			respondProblem(c, http.StatusPreconditionFailed, "VERSION_MISMATCH", "If-Match does not name a version of the pull request")
+			return
+		}
+		c.Request = c.Request.WithContext(services.WithExpectedVersion(c.Request.Context(), version))
+		c.Next()
+	}
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+)
+
+// TestMergeIfMatch merges a PR at version 3 with different If-Match headers.
+// A matching or missing tag merges and returns the new ETag; a stale or
+// foreign tag answers 412 and leaves the PR alone.
+func TestMergeIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		status  int
+		merged  bool
+	}{
+		{"no If-Match", "", http.StatusOK, true},
+		{"matching version", `"3"`, http.StatusOK, true},
+		{"any version", "*", http.StatusOK, true},
+		{"stale version", `"2"`, http.StatusPreconditionFailed, false},
+		{"weak tag", `W/"3"`, http.StatusPreconditionFailed, false},
+		{"unquoted version", "3", http.StatusPreconditionFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs := &versionedPRs{}
+			engine := newEngineWith(t, testServer{prs: prs})
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, apiPrefix+"/pullRequest/merge",
+				strings.NewReader(`{"pull_request_id":"pr-1"}`))
+			r.Header.Set("Authorization", "Bearer admin")
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			engine.ServeHTTP(rec, r)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+			if prs.merged != tt.merged {
+				t.Errorf("merged = %v, want %v", prs.merged, tt.merged)
+			}
+			if !tt.merged {
+				var problem struct{ Code string }
+				if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || problem.Code != "VERSION_MISMATCH" {
+					t.Errorf("problem = %s, want code VERSION_MISMATCH", rec.Body)
+				}
+				return
+			}
+			if got := rec.Header().Get("ETag"); got != `"4"` {
+				t.Errorf("ETag = %q, want the merged version \"4\"", got)
+			}
+		})
+	}
+}
+
+// TestGetPRETag reads a PR with and without the tag of its current version.
+func TestGetPRETag(t *testing.T) {
+	engine := newTestEngine(t)
+	for _, tt := range []struct {
+		ifNoneMatch string
+		status      int
+	}{
+		{"", http.StatusOK},
+		{`"2"`, http.StatusOK},
+		{`"3"`, http.StatusNotModified},
+	} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, apiPrefix+"/pullRequest/get?pull_request_id=pr-1", nil)
+		r.Header.Set("Authorization", "Bearer admin")
+		if tt.ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+		engine.ServeHTTP(rec, r)
+
+		if rec.Code != tt.status {
+			t.Errorf("If-None-Match %q: status = %d, want %d", tt.ifNoneMatch, rec.Code, tt.status)
+		}
+		if got := rec.Header().Get("ETag"); got != `"3"` {
+			t.Errorf("If-None-Match %q: ETag = %q, want \"3\"", tt.ifNoneMatch, got)
+		}
+	}
+}
+
+// versionedPRs keeps one PR at version 3 and checks the expected version of
+// merges the way the PR service does.
+type versionedPRs struct {
+	stubPRs
+	merged bool
+}
+
+func (p *versionedPRs) Merge(ctx context.Context, id string) (*entities.PullRequest, error) {
+	pr := samplePR(id)
+	if v, ok := services.ExpectedVersionFromContext(ctx); ok && v != pr.Version {
+		return nil, errors.ErrVersionMismatch
+	}
+	p.merged = true
+	pr.Version++
+	return pr, nil
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	replayedHeader    = "Idempotent-Replayed"
+	maxIdempotencyKey = 255
+)
+
+// recorder keeps a copy of the response body written through it.
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent makes a POST route safe to retry. The first request with an
+// Idempotency-Key runs and its response is stored; later requests with the
+// key replay that response if they carry the same body and are rejected
+// otherwise. A request that fails with a server error or panics releases the
+// key. Requests without the header run as usual.
+func (s *Server) idempotent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKey {
+			invalidParam(c, idempotencyHeader, "must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			badRequest(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		k, err := s.idem.Begin(c, key, requestHash(c, body))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if k.Completed() {
+			c.Header(replayedHeader, "true")
+			c.Data(k.StatusCode, k.ContentType, k.Body)
+			c.Abort()
+			return
+		}
+
+		// The outcome is stored even if the client has gone away: that is
+		// exactly the case its retry is for.
+		ctx := context.WithoutCancel(c.Request.Context())
+		defer func() {
+			if r := recover(); r != nil {
+				logIdempotencyError(c, s.idem.Abandon(ctx, k))
+				panic(r)
+			}
+		}()
+
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			err = s.idem.Abandon(ctx, k)
+		} else {
+			k.StatusCode = c.Writer.Status()
+			k.ContentType = c.Writer.Header().Get("Content-Type")
+			k.Body = rec.body.Bytes()
+			err = s.idem.Complete(ctx, k)
+		}
+		logIdempotencyError(c, err)
+	}
+}
+
+// logIdempotencyError logs a failure to store or release an idempotency key.
+// The response has been written by then, so the client is not told.
+func logIdempotencyError(c *gin.Context, err error) {
+	if err != nil {
+		log.Printf("request %s %s %s: idempotency key not saved: %v",
+			c.GetString(correlationKey), c.Request.Method, c.Request.URL.Path, err)
+	}
+}
+
+// requestHash identifies a request for its idempotency key: the route, the
+// caller and the body must all match for a response to be replayed.
+func requestHash(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	for _, part := range []string{c.Request.Method, c.Request.URL.Path, principal(c).UserID} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
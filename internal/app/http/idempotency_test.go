@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/gin-gonic/gin"
+)
+
+// TestConcurrentIdempotentCreate sends a PR creation twice with the same key
+// while the first one is still running. The duplicate is rejected with 409
+// IDEMPOTENCY_KEY_IN_USE, and once the first one finishes a retry replays its
+// response without creating the PR again.
+func TestConcurrentIdempotentCreate(t *testing.T) {
+	prs := newBlockingPRs()
+	engine := newEngineWith(t, testServer{
+		prs:  prs,
+		idem: services.NewIdempotencyService(newMemoryKeys(), time.Hour, time.Hour),
+	})
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- sendCreate(engine, "create-1") }()
+	release := <-prs.started
+
+	dup := sendCreate(engine, "create-1")
+	if dup.Code != http.StatusConflict || !strings.Contains(dup.Body.String(), "IDEMPOTENCY_KEY_IN_USE") {
+		t.Errorf("duplicate in flight = %d %s, want 409 IDEMPOTENCY_KEY_IN_USE", dup.Code, dup.Body)
+	}
+
+	close(release)
+	done := <-first
+	if done.Code != http.StatusCreated {
+		t.Fatalf("first request = %d; body: %s", done.Code, done.Body)
+	}
+
+	replay := sendCreate(engine, "create-1")
+	if replay.Code != done.Code || replay.Body.String() != done.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", replay.Code, replay.Body, done.Code, done.Body)
+	}
+	if replay.Header().Get(replayedHeader) != "true" {
+		t.Errorf("replay is missing the %s header", replayedHeader)
+	}
+	if n := prs.calls(); n != 1 {
+		t.Errorf("CreatePR ran %d times, want once", n)
+	}
+}
+
+// TestIdempotentCreatePanics lets the first PR creation panic. The key is
+// released on the way out, so a retry with it runs instead of being told the
+// key is in use.
+func TestIdempotentCreatePanics(t *testing.T) {
+	prs := &panickingPRs{}
+	engine := newEngineWith(t, testServer{
+		prs:  prs,
+		idem: services.NewIdempotencyService(newMemoryKeys(), time.Hour, time.Hour),
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("the first request did not panic")
+			}
+		}()
+		sendCreate(engine, "create-1")
+	}()
+
+	retry := sendCreate(engine, "create-1")
+	if retry.Code != http.StatusCreated {
+		t.Fatalf("retry = %d, want 201; body: %s", retry.Code, retry.Body)
+	}
+	if retry.Header().Get(replayedHeader) != "" {
+		t.Errorf("retry replayed a response although the first request stored none")
+	}
+}
+
+// TestIdempotencyLeaseExpires lets the first request hang past its lease. A
+// retry then claims the key and runs; when the hung request finally finishes
+// it must not replace the response stored for the retry.
+func TestIdempotencyLeaseExpires(t *testing.T) {
+	prs := newBlockingPRs()
+	keys := newMemoryKeys()
+	engine := newEngineWith(t, testServer{
+		prs:  prs,
+		idem: services.NewIdempotencyService(keys, time.Hour, 20*time.Millisecond),
+	})
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- sendCreate(engine, "create-1") }()
+	releaseFirst := <-prs.started
+	time.Sleep(40 * time.Millisecond)
+
+	retried := make(chan *httptest.ResponseRecorder)
+	go func() { retried <- sendCreate(engine, "create-1") }()
+	close(<-prs.started)
+	retry := <-retried
+	if retry.Code != http.StatusCreated || retry.Header().Get(replayedHeader) != "" {
+		t.Fatalf("retry after the lease = %d %s, want a fresh 201", retry.Code, retry.Body)
+	}
+	stored := keys.get("create-1")
+
+	close(releaseFirst)
+	if hung := <-first; hung.Code != http.StatusCreated {
+		t.Fatalf("hung request = %d; body: %s", hung.Code, hung.Body)
+	}
+	if got := keys.get("create-1"); got.LeaseID != stored.LeaseID || string(got.Body) != string(stored.Body) {
+		t.Errorf("the hung request overwrote the retry's response")
+	}
+}
+
+func sendCreate(engine *gin.Engine, key string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, apiPrefix+"/pullRequest/create",
+		strings.NewReader(`{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1"}`))
+	r.Header.Set("Authorization", "Bearer admin")
+	r.Header.Set(idempotencyHeader, key)
+	engine.ServeHTTP(rec, r)
+	return rec
+}
+
+// blockingPRs announces every PR creation on started and holds it until the
+// channel sent along is closed. It numbers the creations so that the
+// responses of two of them differ.
+type blockingPRs struct {
+	stubPRs
+	started chan chan struct{}
+
+	mu sync.Mutex
+	n  int64
+}
+
+func newBlockingPRs() *blockingPRs {
+	return &blockingPRs{started: make(chan chan struct{})}
+}
+
+func (p *blockingPRs) CreatePR(_ context.Context, id, _, _ string, _ entities.PRSize) (*entities.PullRequest, error) {
+	p.mu.Lock()
+	p.n++
+	n := p.n
+	p.mu.Unlock()
+
+	release := make(chan struct{})
+	p.started <- release
+	<-release
+	pr := samplePR(id)
+	pr.Version = n
+	return pr, nil
+}
+
+func (p *blockingPRs) calls() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.n
+}
+
+// panickingPRs panics on the first PR creation and succeeds afterwards.
+type panickingPRs struct {
+	stubPRs
+	panicked bool
+}
+
+func (p *panickingPRs) CreatePR(_ context.Context, id, _, _ string, _ entities.PRSize) (*entities.PullRequest, error) {
+	if !p.panicked {
+		p.panicked = true
+		panic("create failed")
+	}
+	return samplePR(id), nil
+}
+
+// memoryKeys keeps idempotency keys in memory with the semantics of the
+// Postgres repository.
+type memoryKeys struct {
+	mu   sync.Mutex
+	keys map[string]entities.IdempotencyKey
+}
+
+func newMemoryKeys() *memoryKeys {
+	return &memoryKeys{keys: map[string]entities.IdempotencyKey{}}
+}
+
+func (m *memoryKeys) Reserve(_ context.Context, k *entities.IdempotencyKey) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if old, ok := m.keys[k.Key]; ok {
+		if old.ExpiresAt.After(now) && (old.Completed() || old.LeaseExpiresAt.After(now)) {
+			return false, nil
+		}
+	}
+	k.CreatedAt = now
+	m.keys[k.Key] = *k
+	return true, nil
+}
+
+func (m *memoryKeys) Get(_ context.Context, key string) (*entities.IdempotencyKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.keys[key]
+	if !ok || !k.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	return &k, nil
+}
+
+func (m *memoryKeys) Complete(_ context.Context, k *entities.IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.keys[k.Key]; ok && old.LeaseID == k.LeaseID && !old.Completed() {
+		m.keys[k.Key] = *k
+	}
+	return nil
+}
+
+func (m *memoryKeys) Delete(_ context.Context, k *entities.IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.keys[k.Key]; ok && old.LeaseID == k.LeaseID && !old.Completed() {
+		delete(m.keys, k.Key)
+	}
+	return nil
+}
+
+func (m *memoryKeys) get(key string) entities.IdempotencyKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.keys[key]
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/api"
+)
+
+// OpenAPI serves the API description and checks requests against it.
+type OpenAPI struct {
+	doc    []byte
+	router routers.Router
+}
+
+func NewOpenAPI(doc *openapi3.T) (*OpenAPI, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("openapi router: %w", err)
+	}
+	body, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("openapi document: %w", err)
+	}
+	return &OpenAPI{doc: body, router: router}, nil
+}
+
+func (o *OpenAPI) serveSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", o.doc)
+}
+
+func (o *OpenAPI) serveDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", api.SwaggerUI)
+}
+
+// validate rejects requests that do not match the document before they reach
+// the handlers. The deprecated aliases are checked as their /api/v1 routes.
+// Authentication is left to authenticate, which runs first.
+func (o *OpenAPI) validate() gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	}
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			badRequest(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		req := c.Request.Clone(c.Request.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if !strings.HasPrefix(req.URL.Path, apiPrefix+"/") {
+			req.URL.Path = apiPrefix + req.URL.Path
+			req.URL.RawPath = ""
+		}
+		// Handlers bind JSON whatever the content type, so clients that leave
+		// it out keep working.
+		if len(body) > 0 && req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		route, params, err := o.router.FindRoute(req)
+		if err != nil {
+			// Only routes the server registered get here, so a route missing
+			// from the document is left to the handler's own checks.
+			c.Next()
+			return
+		}
+		err = openapi3filter.ValidateRequest(c, &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: params,
+			Route:      route,
+			Options:    options,
+		})
+		if err != nil {
+			p := newProblem(c, http.StatusBadRequest, "INVALID_REQUEST", "request does not match the API description")
+			p.InvalidParams = specViolations(err)
+			render(c, p)
+			return
+		}
+		c.Next()
+	}
+}
+
+// specViolations flattens validation errors into the offending parameters or
+// body fields, body fields named by their JSON path.
+func specViolations(err error) []InvalidParam {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var out []InvalidParam
+		for _, inner := range e {
+			out = append(out, specViolations(inner)...)
+		}
+		return out
+	case *openapi3filter.RequestError:
+		name := "body"
+		if e.Parameter != nil {
+			name = e.Parameter.Name
+		}
+		var out []InvalidParam
+		for _, inner := range flatten(e.Err) {
+			out = append(out, violation(name, inner, e.Reason))
+		}
+		if len(out) == 0 {
+			out = append(out, InvalidParam{Name: name, Reason: e.Reason})
+		}
+		return out
+	default:
+		return []InvalidParam{{Name: "request", Reason: err.Error()}}
+	}
+}
+
+func flatten(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		if err == nil {
+			return nil
+		}
+		return []error{err}
+	}
+	var out []error
+	for _, e := range multi {
+		out = append(out, flatten(e)...)
+	}
+	return out
+}
+
+func violation(name string, err error, reason string) InvalidParam {
+	var schemaErr *openapi3.SchemaError
+	if !stderrors.As(err, &schemaErr) {
+		if reason == "" {
+			reason = err.Error()
+		}
+		return InvalidParam{Name: name, Reason: reason}
+	}
+	if path := schemaErr.JSONPointer(); len(path) > 0 {
+		if name == "body" {
+			name = strings.Join(path, ".")
+		} else {
+			name += "." + strings.Join(path, ".")
+		}
+	}
+	return InvalidParam{Name: name, Reason: schemaErr.Reason}
+}
@@ -0,0 +1,598 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/api"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+func TestResponsesMatchOpenAPI(t *testing.T) {
+	engine := newTestEngine(t)
+	doc := closedDocument(t)
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		header map[string]string
+		status int
+		// stream requests are cancelled once the response has started.
+		stream bool
+	}{
+		{name: "create token", method: "POST", path: "/auth/tokens", body: `{"name":"ci","role":"team-admin","teams":["backend"]}`, status: 201},
+		{name: "list tokens", method: "GET", path: "/auth/tokens", status: 200},
+		{name: "revoke token", method: "DELETE", path: "/auth/tokens?token_id=1", status: 204},
+		{name: "add team", method: "POST", path: "/team/add", body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`, status: 201},
+		{name: "get team", method: "GET", path: "/team/get?team_name=backend", status: 200},
+		{name: "get unknown team", method: "GET", path: "/team/get?team_name=missing", status: 404},
+		{name: "get team without name", method: "GET", path: "/team/get", status: 400},
+		{name: "update team", method: "PATCH", path: "/team/update", body: `{"team_name":"backend","settings":{"reviewers_count":2}}`, status: 200},
+		{name: "rename team", method: "POST", path: "/team/rename", body: `{"team_name":"backend","new_name":"platform"}`, status: 200},
+		{name: "delete team", method: "DELETE", path: "/team/delete?team_name=backend", status: 204},
+		{name: "team tree", method: "GET", path: "/team/tree", status: 200},
+		{name: "team stats", method: "GET", path: "/team/stats?team_name=backend", status: 200},
+		{name: "set is active", method: "POST", path: "/users/setIsActive", body: `{"user_id":"u2","is_active":false}`, status: 200},
+		{name: "set seniority", method: "POST", path: "/users/setSeniority", body: `{"user_id":"u2","seniority":"senior"}`, status: 200},
+		{name: "set max open reviews", method: "POST", path: "/users/setMaxOpenReviews", body: `{"user_id":"u2","max_open_reviews":3}`, status: 200},
+		{name: "get review", method: "GET", path: "/users/getReview?user_id=u2", status: 200},
+		{name: "create PR", method: "POST", path: "/pullRequest/create", body: `{"pull_request_id":"pr-1","pull_request_name":"feature","author_id":"u1"}`, status: 201},
+		{name: "create existing PR", method: "POST", path: "/pullRequest/create", body: `{"pull_request_id":"pr-0","pull_request_name":"feature","author_id":"u1"}`, status: 409},
+		{name: "preview assignment", method: "POST", path: "/pullRequest/previewAssignment", body: `{"author_id":"u1","paths":["a.go"]}`, status: 200},
+		{name: "merge PR", method: "POST", path: "/pullRequest/merge", body: `{"pull_request_id":"pr-1"}`, status: 200},
+		{name: "reassign", method: "POST", path: "/pullRequest/reassign", body: `{"pull_request_id":"pr-1","old_user_id":"u2"}`, status: 200},
+		{name: "decline", method: "POST", path: "/pullRequest/decline", body: `{"pull_request_id":"pr-1","reason":"busy"}`, status: 200},
+		{name: "approve", method: "POST", path: "/pullRequest/approve", body: `{"pull_request_id":"pr-1"}`, status: 200},
+		{name: "close PR", method: "POST", path: "/pullRequest/close", body: `{"pull_request_id":"pr-1"}`, status: 200},
+		{name: "history", method: "GET", path: "/pullRequest/history?pull_request_id=pr-1", status: 200},
+		{name: "assignment explain", method: "GET", path: "/pullRequest/assignmentExplain?pull_request_id=pr-1", status: 200},
+		{name: "get PR", method: "GET", path: "/pullRequest/get?pull_request_id=pr-1", status: 200},
+		{name: "get unchanged PR", method: "GET", path: "/pullRequest/get?pull_request_id=pr-1", header: map[string]string{"If-None-Match": `"3"`}, status: 304},
+		{name: "get unknown PR", method: "GET", path: "/pullRequest/get?pull_request_id=missing", status: 404},
+		{name: "search PRs", method: "GET", path: "/pullRequest/search?status=MERGED", status: 200},
+		{name: "stream events", method: "GET", path: "/events/stream", status: 200, stream: true},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, apiPrefix+tt.path, nil)
+		route, params, err := router.FindRoute(req)
+		if err != nil {
+			t.Fatalf("%s: %s %s is not in the openapi document", tt.name, tt.method, tt.path)
+		}
+		covered[route.Operation.OperationID] = true
+
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, apiPrefix+tt.path, strings.NewReader(tt.body))
+			r.Header.Set("Authorization", "Bearer admin")
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			if tt.stream {
+				ctx, cancel := context.WithCancel(r.Context())
+				cancel()
+				r = r.WithContext(ctx)
+			}
+			engine.ServeHTTP(rec, r)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.status, rec.Body)
+			}
+
+			err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    req,
+					PathParams: params,
+					Route:      route,
+				},
+				Status: rec.Code,
+				Header: rec.Header(),
+				Body:   io.NopCloser(rec.Body),
+				Options: &openapi3filter.Options{
+					IncludeResponseStatus: true,
+					// An event stream has no JSON body to check.
+					ExcludeResponseBody: tt.stream,
+				},
+			})
+			if err != nil {
+				t.Errorf("response does not match the openapi document: %v", err)
+			}
+		})
+	}
+
+	var missing []string
+	for _, path := range doc.Paths.Map() {
+		for _, op := range path.Operations() {
+			if !covered[op.OperationID] {
+				missing = append(missing, op.OperationID)
+			}
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("operations without a response check: %v", missing)
+	}
+}
+
+// TestRoutesAreDocumented checks that every API route the server registers,
+// deprecated aliases included, is described by the openapi document.
+func TestRoutesAreDocumented(t *testing.T) {
+	engine := newTestEngine(t)
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range engine.Routes() {
+		if r.Path == "/openapi.json" || r.Path == "/docs" || strings.HasPrefix(r.Path, "/docs/") {
+			continue
+		}
+		path := r.Path
+		if !strings.HasPrefix(path, apiPrefix+"/") {
+			path = apiPrefix + path
+		}
+		if _, _, err := router.FindRoute(httptest.NewRequest(r.Method, path, nil)); err != nil {
+			t.Errorf("%s %s is not in the openapi document: %v", r.Method, r.Path, err)
+		}
+	}
+}
+
+func TestValidatePassesUndocumentedRoutes(t *testing.T) {
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := NewOpenAPI(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	engine.GET(apiPrefix+"/undocumented", spec.validate(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, apiPrefix+"/undocumented", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+}
+
+func TestDocsServeEmbeddedAssets(t *testing.T) {
+	engine := newTestEngine(t)
+	for _, path := range []string{"/docs", "/docs/assets/swagger-ui-bundle.js", "/docs/assets/swagger-ui.css"} {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("GET %s = %d with %d bytes, want 200 with a body", path, rec.Code, rec.Body.Len())
+		}
+		if strings.Contains(rec.Body.String(), "unpkg.com") {
+			t.Errorf("GET %s loads assets from a CDN", path)
+		}
+	}
+}
+
+// TestPreviewIgnoresIdempotencyKey sends a preview with an Idempotency-Key.
+// The preview changes nothing, so it must run without claiming the key.
+func TestPreviewIgnoresIdempotencyKey(t *testing.T) {
+	idem := &recordingIdempotency{}
+	engine := newEngine(t, idem)
+	for _, path := range []string{"/pullRequest/previewAssignment", "/pullRequest/create"} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, apiPrefix+path,
+			strings.NewReader(`{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1"}`))
+		r.Header.Set("Authorization", "Bearer admin")
+		r.Header.Set("Idempotency-Key", "retry-"+path)
+		engine.ServeHTTP(rec, r)
+		if rec.Code >= 300 {
+			t.Fatalf("POST %s = %d; body: %s", path, rec.Code, rec.Body)
+		}
+	}
+	if want := []string{"retry-/pullRequest/create"}; strings.Join(idem.claimed, ",") != strings.Join(want, ",") {
+		t.Errorf("claimed keys = %v, want %v", idem.claimed, want)
+	}
+}
+
+func newTestEngine(t *testing.T) *gin.Engine {
+	return newEngine(t, stubIdempotency{})
+}
+
+func newEngine(t *testing.T, idem interfaces.IdempotencyService) *gin.Engine {
+	return newEngineWith(t, testServer{idem: idem})
+}
+
+// testServer names the services a test engine uses instead of the stubs.
+type testServer struct {
+	prs   interfaces.PRService
+	users interfaces.UserService
+	teams interfaces.TeamService
+	authn interfaces.Authenticator
+	idem  interfaces.IdempotencyService
+}
+
+func newEngineWith(t *testing.T, ts testServer) *gin.Engine {
+	t.Helper()
+	if ts.prs == nil {
+		ts.prs = stubPRs{}
+	}
+	if ts.users == nil {
+		ts.users = stubUsers{}
+	}
+	if ts.teams == nil {
+		ts.teams = stubTeams{}
+	}
+	if ts.authn == nil {
+		ts.authn = stubAuth{}
+	}
+	if ts.idem == nil {
+		ts.idem = stubIdempotency{}
+	}
+	gin.SetMode(gin.TestMode)
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := NewOpenAPI(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	NewServer(ts.prs, ts.users, ts.teams, stubAuth{}, ts.authn, spec, stubEvents{}, ts.idem).
+		RegisterRoutes(engine)
+	return engine
+}
+
+// closedDocument loads the API description with every object schema closed
+// to undocumented properties, so that a response field the document does not
+// describe fails validation. allOf schemas are merged first: closing their
+// parts would make each part reject the properties of the others.
+func closedDocument(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := api.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := map[*openapi3.Schema]bool{}
+	var closeSchema func(ref *openapi3.SchemaRef)
+	closeSchema = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || closed[ref.Value] {
+			return
+		}
+		s := ref.Value
+		closed[s] = true
+		if len(s.AllOf) > 0 {
+			props := openapi3.Schemas{}
+			var required []string
+			for _, part := range s.AllOf {
+				for name, p := range part.Value.Properties {
+					props[name] = p
+				}
+				required = append(required, part.Value.Required...)
+			}
+			s.Type = &openapi3.Types{openapi3.TypeObject}
+			s.Properties, s.Required, s.AllOf = props, required, nil
+		}
+		for _, p := range s.Properties {
+			closeSchema(p)
+		}
+		closeSchema(s.Items)
+		if s.Type.Is(openapi3.TypeObject) && len(s.Properties) > 0 &&
+			s.AdditionalProperties.Has == nil && s.AdditionalProperties.Schema == nil {
+			no := false
+			s.AdditionalProperties.Has = &no
+		}
+	}
+	for _, path := range doc.Paths.Map() {
+		for _, op := range path.Operations() {
+			for _, resp := range op.Responses.Map() {
+				for _, media := range resp.Value.Content {
+					closeSchema(media.Schema)
+				}
+			}
+		}
+	}
+	return doc
+}
+
+var sampleTime = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func samplePR(id string) *entities.PullRequest {
+	merged := sampleTime.Add(time.Hour)
+	return &entities.PullRequest{
+		PRID:            id,
+		Name:            "feature",
+		AuthorID:        "u1",
+		Status:          entities.PRStatusMerged,
+		CreatedAt:       sampleTime,
+		MergedAt:        &merged,
+		PRSize:          entities.PRSize{LinesChanged: 120, FilesChanged: 4},
+		Overloaded:      true,
+		Version:         3,
+		Reviewers:       []string{"u2"},
+		LeadReviewers:   []string{"u3"},
+		ShadowReviewers: []string{"u4"},
+		Approvals:       []string{"u2"},
+	}
+}
+
+func sampleUser(id string) *entities.User {
+	limit := 3
+	return &entities.User{
+		UserID:         id,
+		Username:       "Bob",
+		IsActive:       true,
+		TeamName:       "backend",
+		Seniority:      entities.SenioritySenior,
+		MaxOpenReviews: &limit,
+	}
+}
+
+func sampleTeam(name string) *entities.Team {
+	return &entities.Team{
+		TeamName:   name,
+		ParentTeam: "engineering",
+		Settings: entities.TeamSettings{
+			ReviewersCount:    2,
+			Strategy:          entities.StrategySpreadKnowledge,
+			PairingWindowDays: entities.DefaultPairingWindowDays,
+			PairingDecay:      entities.DefaultPairingDecay,
+			MaxOpenReviews:    5,
+			OverloadPolicy:    entities.OverloadQueue,
+		},
+		Members: []entities.TeamMember{{
+			User:         *sampleUser("u2"),
+			IsPrimary:    true,
+			ReviewWeight: 1,
+			Role:         entities.MemberRoleLead,
+			OpenReviews:  1,
+		}},
+	}
+}
+
+func sampleDecision() entities.AssignmentDecision {
+	return entities.AssignmentDecision{
+		DecisionID: 1,
+		PRID:       "pr-1",
+		ReviewerID: "u2",
+		Slot:       entities.ReviewerSlotRegular,
+		Strategy:   entities.StrategyRandom,
+		Candidates: []entities.CandidateScore{
+			{UserID: "u2", TeamName: "backend", Weight: 1, Factor: 1, Score: 1, Probability: 1},
+		},
+		Excluded: []entities.ExcludedCandidate{
+			{UserID: "u1", TeamName: "backend", Reason: entities.ExclusionAuthor},
+		},
+		CreatedAt: sampleTime,
+	}
+}
+
+// The stubs answer every call with fully populated entities, so that each
+// documented field appears in some response. Unknown ids fail like the real
+// services do.
+
+type stubAuth struct{}
+
+func (stubAuth) Authenticate(context.Context, string) (*entities.Principal, error) {
+	return &entities.Principal{OrgID: "acme", UserID: "u1", Role: entities.RoleAdmin}, nil
+}
+
+func (stubAuth) CreateToken(_ context.Context, t *entities.APIToken) (string, error) {
+	t.TokenID, t.OrgID, t.CreatedAt = 1, "acme", sampleTime
+	return "secret", nil
+}
+
+func (stubAuth) EnsureToken(context.Context, *entities.APIToken, string) error { return nil }
+
+func (stubAuth) ListTokens(context.Context) ([]entities.APIToken, error) {
+	expires := sampleTime.Add(24 * time.Hour)
+	return []entities.APIToken{{
+		TokenID:    1,
+		OrgID:      "acme",
+		Name:       "ci",
+		UserID:     "u1",
+		Role:       entities.RoleTeamAdmin,
+		Teams:      []string{"backend"},
+		CreatedAt:  sampleTime,
+		ExpiresAt:  &expires,
+		LastUsedAt: &expires,
+		RevokedAt:  &expires,
+	}}, nil
+}
+
+func (stubAuth) RevokeToken(context.Context, int64) error { return nil }
+
+type stubTeams struct{}
+
+func (stubTeams) CreateTeam(_ context.Context, team *entities.Team, _ bool) (*entities.Team, error) {
+	return sampleTeam(team.TeamName), nil
+}
+
+func (stubTeams) GetTeam(_ context.Context, name string) (*entities.Team, error) {
+	if name != "backend" {
+		return nil, errors.ErrTeamNotFound
+	}
+	return sampleTeam(name), nil
+}
+
+func (stubTeams) UpdateTeam(_ context.Context, name string, _ entities.TeamUpdate) (*entities.Team, error) {
+	return sampleTeam(name), nil
+}
+
+func (stubTeams) RenameTeam(_ context.Context, _, newName string) (*entities.Team, error) {
+	return sampleTeam(newName), nil
+}
+
+func (stubTeams) DeleteTeam(context.Context, string, string) error { return nil }
+
+func (stubTeams) TeamTree(context.Context, string) ([]entities.TeamNode, error) {
+	return []entities.TeamNode{{TeamName: "engineering", Children: []entities.TeamNode{{TeamName: "backend"}}}}, nil
+}
+
+func (stubTeams) GetStats(_ context.Context, name string) (*entities.TeamStats, error) {
+	counters := entities.TeamStatsCounters{Members: 3, ActiveMembers: 2, OpenPRs: 1, MergedPRs: 4, ClosedPRs: 1, ReviewAssignments: 9, Declines: 2}
+	return &entities.TeamStats{
+		TeamName:          name,
+		Subteams:          []string{"payments"},
+		Own:               counters,
+		Rollup:            counters,
+		PairingWindowDays: entities.DefaultPairingWindowDays,
+		Pairings:          []entities.ReviewPairing{{AuthorID: "u1", ReviewerID: "u2", Count: 2}},
+	}, nil
+}
+
+type stubUsers struct{}
+
+func (stubUsers) SetIsActive(_ context.Context, id string, _ bool) (*entities.User, error) {
+	return sampleUser(id), nil
+}
+
+func (stubUsers) SetSeniority(_ context.Context, id string, _ entities.Seniority) (*entities.User, error) {
+	return sampleUser(id), nil
+}
+
+func (stubUsers) SetMaxOpenReviews(_ context.Context, id string, _ *int) (*entities.User, error) {
+	return sampleUser(id), nil
+}
+
+func (stubUsers) GetByID(_ context.Context, id string) (*entities.User, error) {
+	return sampleUser(id), nil
+}
+
+func (stubUsers) ListByTeam(context.Context, string) ([]entities.User, error) {
+	return []entities.User{*sampleUser("u2")}, nil
+}
+
+func (stubUsers) ListActiveByTeam(context.Context, string) ([]entities.User, error) {
+	return []entities.User{*sampleUser("u2")}, nil
+}
+
+type stubPRs struct{}
+
+func (stubPRs) CreatePR(_ context.Context, id, _, _ string, _ entities.PRSize) (*entities.PullRequest, error) {
+	if id == "pr-0" {
+		return nil, errors.ErrPRExists
+	}
+	return samplePR(id), nil
+}
+
+func (stubPRs) PreviewAssignment(context.Context, string, string, entities.PRSize) (*entities.AssignmentPreview, error) {
+	d := sampleDecision()
+	return &entities.AssignmentPreview{
+		AuthorID:  "u1",
+		TeamName:  "backend",
+		Strategy:  entities.StrategyRandom,
+		Reviewers: []entities.ReviewerAssignment{{ReviewerID: "u2", Slot: entities.ReviewerSlotRegular}},
+		Ranking:   d.Candidates,
+		Decisions: []entities.AssignmentDecision{d},
+	}, nil
+}
+
+func (stubPRs) ReplaceReviewer(_ context.Context, id, _ string) (*entities.PullRequest, string, error) {
+	return samplePR(id), "u5", nil
+}
+
+func (stubPRs) Decline(_ context.Context, id, _, _ string) (*entities.PullRequest, string, error) {
+	return samplePR(id), "u5", nil
+}
+
+func (stubPRs) Approve(_ context.Context, id, _ string) (*entities.PullRequest, error) {
+	return samplePR(id), nil
+}
+
+func (stubPRs) Merge(_ context.Context, id string) (*entities.PullRequest, error) {
+	return samplePR(id), nil
+}
+
+func (stubPRs) Close(_ context.Context, id string) (*entities.PullRequest, error) {
+	return samplePR(id), nil
+}
+
+func (stubPRs) History(_ context.Context, id string) ([]entities.PREvent, error) {
+	return []entities.PREvent{{
+		EventID:       1,
+		PRID:          id,
+		Type:          entities.PREventReviewerReplaced,
+		OldReviewerID: "u2",
+		NewReviewerID: "u5",
+		ActorID:       "u2",
+		Reason:        "busy",
+		CreatedAt:     sampleTime,
+	}}, nil
+}
+
+func (stubPRs) EventsSince(context.Context, entities.PREventFilter) (*entities.PREventPage, error) {
+	return &entities.PREventPage{}, nil
+}
+
+func (stubPRs) LastEventID(context.Context) (int64, error) { return 0, nil }
+
+func (stubPRs) ExplainAssignment(context.Context, string, string) ([]entities.AssignmentDecision, error) {
+	return []entities.AssignmentDecision{sampleDecision()}, nil
+}
+
+func (stubPRs) GetPR(_ context.Context, id string) (*entities.PullRequest, error) {
+	if id == "missing" {
+		return nil, errors.ErrPRNotFound
+	}
+	return samplePR(id), nil
+}
+
+func (stubPRs) Search(context.Context, entities.PRSearchFilter, string) (*entities.PRPage, error) {
+	return &entities.PRPage{PullRequests: []entities.PullRequest{*samplePR("pr-1")}, NextCursor: "next"}, nil
+}
+
+func (stubPRs) ListByReviewer(context.Context, entities.ReviewListFilter, string) (*entities.ReviewPage, error) {
+	return &entities.ReviewPage{
+		PRPage: entities.PRPage{PullRequests: []entities.PullRequest{*samplePR("pr-1")}, NextCursor: "next"},
+		Counts: entities.ReviewCounts{Total: 1, Merged: 1, Approved: 1, Declined: 1},
+	}, nil
+}
+
+type stubEvents struct{}
+
+func (stubEvents) Subscribe(string) (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
+
+type stubIdempotency struct{}
+
+func (stubIdempotency) Begin(_ context.Context, key, _ string) (*entities.IdempotencyKey, error) {
+	return &entities.IdempotencyKey{Key: key}, nil
+}
+
+func (stubIdempotency) Complete(context.Context, *entities.IdempotencyKey) error { return nil }
+
+func (stubIdempotency) Abandon(context.Context, *entities.IdempotencyKey) error { return nil }
+
+// recordingIdempotency remembers the keys requests claimed.
+type recordingIdempotency struct {
+	stubIdempotency
+	claimed []string
+}
+
+func (r *recordingIdempotency) Begin(_ context.Context, key, _ string) (*entities.IdempotencyKey, error) {
+	r.claimed = append(r.claimed, key)
+	return &entities.IdempotencyKey{Key: key}, nil
+}
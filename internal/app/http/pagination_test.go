@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+// TestSearchCursor passes the cursor of the first page on to the service and
+// answers an unknown cursor with 400 INVALID_CURSOR.
+func TestSearchCursor(t *testing.T) {
+	prs := &pagedPRs{}
+	engine := newEngineWith(t, testServer{prs: prs})
+	for _, tt := range []struct {
+		cursor string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"next", http.StatusOK},
+		{"bogus", http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, apiPrefix+"/pullRequest/search?cursor="+tt.cursor, nil)
+		r.Header.Set("Authorization", "Bearer admin")
+		engine.ServeHTTP(rec, r)
+
+		if rec.Code != tt.status {
+			t.Fatalf("cursor %q: status = %d, want %d; body: %s", tt.cursor, rec.Code, tt.status, rec.Body)
+		}
+		if prs.cursor != tt.cursor {
+			t.Errorf("service got cursor %q, want %q", prs.cursor, tt.cursor)
+		}
+		if tt.status == http.StatusBadRequest {
+			var problem struct{ Code string }
+			if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || problem.Code != "INVALID_CURSOR" {
+				t.Errorf("cursor %q: problem = %s, want code INVALID_CURSOR", tt.cursor, rec.Body)
+			}
+		}
+	}
+}
+
+// TestReviewListPages reads the reviews of a user page by page under
+// /api/v1 and all at once on the deprecated route without a limit.
+func TestReviewListPages(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		all    bool
+		limit  int
+		cursor string
+		next   string
+	}{
+		{"first page", apiPrefix + "/users/getReview?user_id=u2&limit=1", false, 1, "", "next"},
+		{"next page", apiPrefix + "/users/getReview?user_id=u2&limit=1&cursor=next", false, 1, "next", ""},
+		{"default page size", apiPrefix + "/users/getReview?user_id=u2", false, 0, "", "next"},
+		{"deprecated route", "/users/getReview?user_id=u2", true, 0, "", ""},
+		{"deprecated route with a limit", "/users/getReview?user_id=u2&limit=1", false, 1, "", "next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs := &pagedPRs{}
+			engine := newEngineWith(t, testServer{prs: prs})
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r.Header.Set("Authorization", "Bearer admin")
+			engine.ServeHTTP(rec, r)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d; body: %s", rec.Code, rec.Body)
+			}
+			if prs.reviews.All != tt.all || prs.reviews.Limit != tt.limit || prs.cursor != tt.cursor {
+				t.Errorf("service got %+v after %q, want all=%v, limit=%d after %q",
+					prs.reviews, prs.cursor, tt.all, tt.limit, tt.cursor)
+			}
+			var body struct {
+				NextCursor string `json:"next_cursor"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.NextCursor != tt.next {
+				t.Errorf("next_cursor in %s, want %q", rec.Body, tt.next)
+			}
+		})
+	}
+}
+
+// pagedPRs serves two pages: the first one and the one after the "next"
+// cursor. Other cursors are invalid.
+type pagedPRs struct {
+	stubPRs
+	cursor  string
+	reviews entities.ReviewListFilter
+}
+
+func (p *pagedPRs) page(cursor string) (*entities.PRPage, error) {
+	p.cursor = cursor
+	switch cursor {
+	case "":
+		return &entities.PRPage{PullRequests: []entities.PullRequest{*samplePR("pr-2")}, NextCursor: "next"}, nil
+	case "next":
+		return &entities.PRPage{PullRequests: []entities.PullRequest{*samplePR("pr-1")}}, nil
+	}
+	return nil, errors.ErrInvalidCursor
+}
+
+func (p *pagedPRs) Search(_ context.Context, _ entities.PRSearchFilter, cursor string) (*entities.PRPage, error) {
+	return p.page(cursor)
+}
+
+func (p *pagedPRs) ListByReviewer(_ context.Context, f entities.ReviewListFilter, cursor string) (*entities.ReviewPage, error) {
+	p.reviews = f
+	page, err := p.page(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if f.All {
+		page = &entities.PRPage{PullRequests: []entities.PullRequest{*samplePR("pr-2"), *samplePR("pr-1")}}
+	}
+	return &entities.ReviewPage{PRPage: *page, Counts: entities.ReviewCounts{Total: 2}}, nil
+}
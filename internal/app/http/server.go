@@ -2,11 +2,13 @@ package http
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/f4ke-n0name/avito/api"
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
-	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
 	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
 )
 
@@ -14,59 +16,218 @@ type Server struct {
 	pr    interfaces.PRService
 	users interfaces.UserService
 	teams interfaces.TeamService
+	auth  interfaces.AuthService
+	// authn checks bearer credentials: the API tokens of auth or, in JWT
+	// mode, tokens issued by the company SSO.
+	authn interfaces.Authenticator
+	spec  *OpenAPI
+	// events wakes the event streams when the PR log grows.
+	events interfaces.EventSubscriber
+	// idem stores responses of requests sent with an Idempotency-Key.
+	idem interfaces.IdempotencyService
 }
 
-func NewServer(pr interfaces.PRService, users interfaces.UserService, teams interfaces.TeamService) *Server {
-	return &Server{pr: pr, users: users, teams: teams}
+func NewServer(
+	pr interfaces.PRService,
+	users interfaces.UserService,
+	teams interfaces.TeamService,
+	auth interfaces.AuthService,
+	authn interfaces.Authenticator,
+	spec *OpenAPI,
+	events interfaces.EventSubscriber,
+	idem interfaces.IdempotencyService,
+) *Server {
+	return &Server{
+		pr:     pr,
+		users:  users,
+		teams:  teams,
+		auth:   auth,
+		authn:  authn,
+		spec:   spec,
+		events: events,
+		idem:   idem,
+	}
 }
 
+// apiPrefix is the versioned API. The same routes without it are deprecated
+// aliases kept for existing clients.
+const apiPrefix = "/api/v1"
+
 func (s *Server) RegisterRoutes(r *gin.Engine) {
-	r.POST("/team/add", s.addTeam)
-	r.GET("/team/get", s.getTeam)
+	r.ContextWithFallback = true
+	r.Use(correlate())
+
+	r.GET("/openapi.json", s.spec.serveSpec)
+	r.GET("/docs", s.spec.serveDocs)
+	r.StaticFS("/docs/assets", http.FS(api.SwaggerAssets()))
+
+	s.registerAPI(r.Group(apiPrefix, authenticate(s.authn), s.spec.validate()))
+	s.registerAPI(r.Group("", deprecated(), authenticate(s.authn), s.spec.validate()))
+}
+
+func (s *Server) registerAPI(r *gin.RouterGroup) {
+	// Every POST honours an Idempotency-Key except token creation, whose
+	// response carries the token secret that is never stored, and the
+	// assignment preview, which changes nothing.
+	idem := s.idempotent()
+	// PR mutations also honour If-Match with the ETag of the PR.
+	match := ifMatch()
+
+	r.POST("/auth/tokens", requireAdmin(), s.createToken)
+	r.GET("/auth/tokens", requireAdmin(), s.listTokens)
+	r.DELETE("/auth/tokens", requireAdmin(), s.revokeToken)
 
-	r.POST("/users/setIsActive", s.setIsActive)
+	r.POST("/team/add", requireAdmin(), idem, s.addTeam)
+	r.GET("/team/get", s.getTeam)
+	r.PATCH("/team/update", s.updateTeam)
+	r.POST("/team/rename", idem, s.renameTeam)
+	r.DELETE("/team/delete", s.deleteTeam)
+	r.GET("/team/tree", s.teamTree)
+	r.GET("/team/stats", s.teamStats)
+
+	r.POST("/users/setIsActive", idem, s.setIsActive)
+	r.POST("/users/setSeniority", idem, s.setSeniority)
+	r.POST("/users/setMaxOpenReviews", idem, s.setMaxOpenReviews)
 	r.GET("/users/getReview", s.getReviewList)
 
-	r.POST("/pullRequest/create", s.createPR)
-	r.POST("/pullRequest/merge", s.mergePR)
-	r.POST("/pullRequest/reassign", s.reassign)
+	r.POST("/pullRequest/create", idem, s.createPR)
+	r.POST("/pullRequest/previewAssignment", s.previewAssignment)
+	r.POST("/pullRequest/merge", idem, match, s.mergePR)
+	r.POST("/pullRequest/reassign", idem, match, s.reassign)
+	r.POST("/pullRequest/decline", idem, match, s.decline)
+	r.POST("/pullRequest/approve", idem, match, s.approve)
+	r.POST("/pullRequest/close", idem, match, s.closePR)
+	r.GET("/pullRequest/history", s.history)
+	r.GET("/pullRequest/assignmentExplain", s.assignmentExplain)
+	r.GET("/pullRequest/get", s.getPR)
+	r.GET("/pullRequest/search", s.searchPRs)
+
+	r.GET("/events/stream", s.streamEvents)
+}
+
+// TeamMemberRequest describes a member of a team. ReviewWeight scales how
+// often the member is picked as a reviewer and defaults to 1. An empty
+// seniority keeps the one already stored for the user.
+type TeamMemberRequest struct {
+	UserID       string   `json:"user_id" binding:"required"`
+	Username     string   `json:"username" binding:"required"`
+	IsActive     bool     `json:"is_active"`
+	Seniority    string   `json:"seniority" binding:"omitempty,oneof=junior middle senior"`
+	Role         string   `json:"role" binding:"omitempty,oneof=member lead"`
+	ReviewWeight *float64 `json:"review_weight" binding:"omitempty,gte=0"`
+}
+
+func (m TeamMemberRequest) toMember() entities.TeamMember {
+	member := entities.TeamMember{
+		User: entities.User{
+			UserID:    m.UserID,
+			Username:  m.Username,
+			IsActive:  m.IsActive,
+			Seniority: entities.Seniority(m.Seniority),
+		},
+		ReviewWeight: 1,
+		Role:         entities.MemberRoleMember,
+	}
+	if m.ReviewWeight != nil {
+		member.ReviewWeight = *m.ReviewWeight
+	}
+	if m.Role != "" {
+		member.Role = entities.MemberRole(m.Role)
+	}
+	return member
 }
 
 type TeamAddRequest struct {
-	TeamName string `json:"team_name" binding:"required"`
-	Members  []struct {
-		UserID   string `json:"user_id" binding:"required"`
-		Username string `json:"username" binding:"required"`
-		IsActive bool   `json:"is_active"`
-	} `json:"members" binding:"required"`
+	TeamName            string              `json:"team_name" binding:"required"`
+	ParentTeam          string              `json:"parent_team"`
+	Members             []TeamMemberRequest `json:"members" binding:"required,dive"`
+	MoveExistingMembers bool                `json:"move_existing_members"`
+}
+
+// TeamSettingsRequest replaces all settings of a team at once. Omitted
+// strategy fields fall back to random selection with the default window and
+// decay.
+type TeamSettingsRequest struct {
+	ReviewersCount     int  `json:"reviewers_count" binding:"required,min=1"`
+	EscalateToParent   bool `json:"escalate_to_parent"`
+	LeadReviewMinLines int  `json:"lead_review_min_lines" binding:"min=0"`
+	LeadReviewMinFiles int  `json:"lead_review_min_files" binding:"min=0"`
+	MentorshipRequired bool `json:"mentorship_required"`
+	ShadowJuniors      bool `json:"shadow_juniors"`
+
+	Strategy          string   `json:"strategy" binding:"omitempty,oneof=random spread_knowledge"`
+	PairingWindowDays int      `json:"pairing_window_days" binding:"omitempty,min=1"`
+	PairingDecay      *float64 `json:"pairing_decay" binding:"omitempty,gt=0,lte=1"`
+
+	MaxOpenReviews int    `json:"max_open_reviews" binding:"min=0"`
+	OverloadPolicy string `json:"overload_policy" binding:"omitempty,oneof=assign queue fail"`
+}
+
+func (r TeamSettingsRequest) toSettings() entities.TeamSettings {
+	settings := entities.TeamSettings{
+		ReviewersCount:     r.ReviewersCount,
+		EscalateToParent:   r.EscalateToParent,
+		LeadReviewMinLines: r.LeadReviewMinLines,
+		LeadReviewMinFiles: r.LeadReviewMinFiles,
+		MentorshipRequired: r.MentorshipRequired,
+		ShadowJuniors:      r.ShadowJuniors,
+		Strategy:           entities.StrategyRandom,
+		PairingWindowDays:  entities.DefaultPairingWindowDays,
+		PairingDecay:       entities.DefaultPairingDecay,
+		MaxOpenReviews:     r.MaxOpenReviews,
+		OverloadPolicy:     entities.OverloadAssign,
+	}
+	if r.Strategy != "" {
+		settings.Strategy = entities.SelectionStrategy(r.Strategy)
+	}
+	if r.PairingWindowDays != 0 {
+		settings.PairingWindowDays = r.PairingWindowDays
+	}
+	if r.PairingDecay != nil {
+		settings.PairingDecay = *r.PairingDecay
+	}
+	if r.OverloadPolicy != "" {
+		settings.OverloadPolicy = entities.OverloadPolicy(r.OverloadPolicy)
+	}
+	return settings
+}
+
+// TeamMembershipRequest adds a user to an existing team.
+type TeamMembershipRequest struct {
+	TeamMemberRequest
+	IsPrimary bool `json:"is_primary"`
+}
+
+type TeamUpdateRequest struct {
+	TeamName            string                  `json:"team_name" binding:"required"`
+	AddMembers          []TeamMembershipRequest `json:"add_members" binding:"dive"`
+	RemoveMembers       []string                `json:"remove_members"`
+	Settings            *TeamSettingsRequest    `json:"settings"`
+	ParentTeam          *string                 `json:"parent_team"`
+	MoveExistingMembers bool                    `json:"move_existing_members"`
 }
 
 func (s *Server) addTeam(c *gin.Context) {
 	var req TeamAddRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, newBadRequest(err))
+		badRequest(c, err)
 		return
 	}
 
 	team := &entities.Team{
-		TeamName: req.TeamName,
+		TeamName:   req.TeamName,
+		ParentTeam: req.ParentTeam,
 	}
 	for _, m := range req.Members {
-		team.Members = append(team.Members, entities.User{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
-			TeamName: req.TeamName,
-		})
+		member := m.toMember()
+		member.TeamName = req.TeamName
+		member.IsPrimary = true
+		team.Members = append(team.Members, member)
 	}
 
-	created, err := s.teams.CreateTeam(c, team)
+	created, err := s.teams.CreateTeam(c, team, req.MoveExistingMembers)
 	if err != nil {
-		if err == errors.ErrTeamExists {
-			c.JSON(http.StatusBadRequest, errorResponse("TEAM_EXISTS", "team_name already exists"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, newInternal(err))
+		respondError(c, err)
 		return
 	}
 
@@ -77,25 +238,182 @@ func (s *Server) getTeam(c *gin.Context) {
 	name := c.Query("team_name")
 	t, err := s.teams.GetTeam(c, name)
 	if err != nil {
-		c.JSON(http.StatusNotFound, errorResponse("NOT_FOUND", "team not found"))
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, t)
 }
 
+func (s *Server) updateTeam(c *gin.Context) {
+	var req TeamUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	p := principal(c)
+	if !p.ManagesTeam(req.TeamName) || (req.ParentTeam != nil && *req.ParentTeam != "" && !p.ManagesTeam(*req.ParentTeam)) {
+		forbidden(c)
+		return
+	}
+	// Adding a member must not become a way to take users from teams the
+	// caller does not administer.
+	for _, m := range req.AddMembers {
+		if !s.requireNewOrManagedUser(c, m.UserID) {
+			return
+		}
+	}
+
+	upd := entities.TeamUpdate{
+		RemoveMembers:       req.RemoveMembers,
+		ParentTeam:          req.ParentTeam,
+		MoveExistingMembers: req.MoveExistingMembers,
+	}
+	for _, m := range req.AddMembers {
+		member := m.toMember()
+		member.IsPrimary = m.IsPrimary
+		upd.AddMembers = append(upd.AddMembers, member)
+	}
+	if req.Settings != nil {
+		settings := req.Settings.toSettings()
+		upd.Settings = &settings
+	}
+
+	team, err := s.teams.UpdateTeam(c, req.TeamName, upd)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": team})
+}
+
+func (s *Server) renameTeam(c *gin.Context) {
+	var req struct {
+		TeamName string `json:"team_name" binding:"required"`
+		NewName  string `json:"new_name" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if !principal(c).ManagesTeam(req.TeamName) {
+		forbidden(c)
+		return
+	}
+
+	team, err := s.teams.RenameTeam(c, req.TeamName, req.NewName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team": team})
+}
+
+func (s *Server) deleteTeam(c *gin.Context) {
+	var req struct {
+		TeamName   string `form:"team_name" binding:"required"`
+		TargetTeam string `form:"target_team"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if p := principal(c); !p.ManagesTeam(req.TeamName) || (req.TargetTeam != "" && !p.ManagesTeam(req.TargetTeam)) {
+		forbidden(c)
+		return
+	}
+
+	if err := s.teams.DeleteTeam(c, req.TeamName, req.TargetTeam); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) teamTree(c *gin.Context) {
+	tree, err := s.teams.TeamTree(c, c.Query("team_name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"teams": tree})
+}
+
+func (s *Server) teamStats(c *gin.Context) {
+	stats, err := s.teams.GetStats(c, c.Query("team_name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
 func (s *Server) setIsActive(c *gin.Context) {
 	var req struct {
 		UserID   string `json:"user_id" binding:"required"`
 		IsActive bool   `json:"is_active"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, newBadRequest(err))
+		badRequest(c, err)
+		return
+	}
+	if !s.requireUserAdmin(c, req.UserID) {
 		return
 	}
 
 	u, err := s.users.SetIsActive(c, req.UserID, req.IsActive)
 	if err != nil {
-		c.JSON(http.StatusNotFound, errorResponse("NOT_FOUND", "user not found"))
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": u})
+}
+
+func (s *Server) setSeniority(c *gin.Context) {
+	var req struct {
+		UserID    string `json:"user_id" binding:"required"`
+		Seniority string `json:"seniority" binding:"required,oneof=junior middle senior"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if !s.requireUserAdmin(c, req.UserID) {
+		return
+	}
+
+	u, err := s.users.SetSeniority(c, req.UserID, entities.Seniority(req.Seniority))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": u})
+}
+
+// setMaxOpenReviews sets a user's review limit; a null limit falls back to
+// the team default.
+func (s *Server) setMaxOpenReviews(c *gin.Context) {
+	var req struct {
+		UserID         string `json:"user_id" binding:"required"`
+		MaxOpenReviews *int   `json:"max_open_reviews" binding:"omitempty,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if !s.requireUserAdmin(c, req.UserID) {
+		return
+	}
+
+	u, err := s.users.SetMaxOpenReviews(c, req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -103,46 +421,110 @@ func (s *Server) setIsActive(c *gin.Context) {
 }
 
 func (s *Server) getReviewList(c *gin.Context) {
-	uid := c.Query("user_id")
+	var req struct {
+		UserID      string `form:"user_id" binding:"required"`
+		Status      string `form:"status" binding:"omitempty,oneof=OPEN MERGED CLOSED"`
+		OnlyPending bool   `form:"only_pending"`
+		Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+		Cursor      string `form:"cursor"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
 
-	list, err := s.pr.ListByReviewer(c, uid)
+	page, err := s.pr.ListByReviewer(c, entities.ReviewListFilter{
+		ReviewerID:  req.UserID,
+		Status:      entities.PRStatus(req.Status),
+		OnlyPending: req.OnlyPending,
+		Limit:       req.Limit,
+		// The deprecated route listed every review before it was paginated.
+		All: c.GetBool(legacyKey) && req.Limit == 0,
+	}, req.Cursor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, newInternal(err))
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"user_id":       uid,
-		"pull_requests": list,
+		"user_id":       req.UserID,
+		"pull_requests": page.PullRequests,
+		"next_cursor":   page.NextCursor,
+		"counts": gin.H{
+			"total":    page.Counts.Total,
+			"open":     page.Counts.Open,
+			"merged":   page.Counts.Merged,
+			"closed":   page.Counts.Closed,
+			"approved": page.Counts.Approved,
+			"declined": page.Counts.Declined,
+		},
 	})
 }
 
 func (s *Server) createPR(c *gin.Context) {
 	var req struct {
-		PRID   string `json:"pull_request_id" binding:"required"`
-		Name   string `json:"pull_request_name" binding:"required"`
-		Author string `json:"author_id" binding:"required"`
+		PRID         string `json:"pull_request_id" binding:"required"`
+		Name         string `json:"pull_request_name" binding:"required"`
+		Author       string `json:"author_id"`
+		LinesChanged int    `json:"lines_changed" binding:"min=0"`
+		FilesChanged int    `json:"files_changed" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if req.Author == "" {
+		// The service defaults the author to the caller.
+		if principal(c).UserID == "" {
+			respondProblem(c, http.StatusBadRequest, "AUTHOR_REQUIRED", "author_id is required for callers without a user")
+			return
+		}
+	} else if !s.actsFor(c, req.Author) {
+		forbidden(c)
+		return
+	}
+
+	pr, err := s.pr.CreatePR(c, req.PRID, req.Name, req.Author, entities.PRSize{
+		LinesChanged: req.LinesChanged,
+		FilesChanged: req.FilesChanged,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
 	}
 
+	respondPR(c, http.StatusCreated, pr, gin.H{"pr": pr})
+}
+
+// previewAssignment shows who createPR would pick, without creating the PR.
+// Paths only count as changed files when files_changed is not given.
+func (s *Server) previewAssignment(c *gin.Context) {
+	var req struct {
+		PRID         string   `json:"pull_request_id"`
+		Author       string   `json:"author_id"`
+		Paths        []string `json:"paths"`
+		LinesChanged int      `json:"lines_changed" binding:"min=0"`
+		FilesChanged int      `json:"files_changed" binding:"min=0"`
+	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, newBadRequest(err))
+		badRequest(c, err)
 		return
 	}
+	if req.FilesChanged == 0 {
+		req.FilesChanged = len(req.Paths)
+	}
 
-	pr, err := s.pr.CreatePR(c, req.PRID, req.Name, req.Author)
+	preview, err := s.pr.PreviewAssignment(c, req.PRID, req.Author, entities.PRSize{
+		LinesChanged: req.LinesChanged,
+		FilesChanged: req.FilesChanged,
+	})
 	if err != nil {
-		switch err {
-		case errors.ErrUserNotFound:
-			c.JSON(http.StatusNotFound, errorResponse("NOT_FOUND", "author not found"))
-		case errors.ErrPRExists:
-			c.JSON(http.StatusConflict, errorResponse("PR_EXISTS", "PR id already exists"))
-		default:
-			c.JSON(http.StatusInternalServerError, newInternal(err))
-		}
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"pr": pr})
+	c.JSON(http.StatusOK, gin.H{"preview": preview})
 }
 
 func (s *Server) mergePR(c *gin.Context) {
@@ -150,21 +532,20 @@ func (s *Server) mergePR(c *gin.Context) {
 		PRID string `json:"pull_request_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, newBadRequest(err))
+		badRequest(c, err)
+		return
+	}
+	if !s.authorizePR(c, req.PRID) {
 		return
 	}
 
 	pr, err := s.pr.Merge(c, req.PRID)
 	if err != nil {
-		if err == errors.ErrPRNotFound {
-			c.JSON(http.StatusNotFound, errorResponse("NOT_FOUND", "PR not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, newInternal(err))
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"pr": pr})
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr})
 }
 
 func (s *Server) reassign(c *gin.Context) {
@@ -173,43 +554,178 @@ func (s *Server) reassign(c *gin.Context) {
 		OldUserID string `json:"old_user_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, newBadRequest(err))
+		badRequest(c, err)
+		return
+	}
+	if !s.authorizePR(c, req.PRID, req.OldUserID) {
 		return
 	}
 
 	pr, newID, err := s.pr.ReplaceReviewer(c, req.PRID, req.OldUserID)
 	if err != nil {
-		switch err {
-		case errors.ErrPRNotFound, errors.ErrUserNotFound:
-			c.JSON(http.StatusNotFound, errorResponse("NOT_FOUND", "PR or user not found"))
-		case errors.ErrPRAlreadyMerged:
-			c.JSON(http.StatusConflict, errorResponse("PR_MERGED", "cannot reassign on merged PR"))
-		case errors.ErrNoSuchReviewer:
-			c.JSON(http.StatusConflict, errorResponse("NOT_ASSIGNED", "reviewer is not assigned to this PR"))
-		case errors.ErrNoCandidates:
-			c.JSON(http.StatusConflict, errorResponse("NO_CANDIDATE", "no active replacement candidate in team"))
-		default:
-			c.JSON(http.StatusInternalServerError, newInternal(err))
-		}
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"pr": pr, "replaced_by": newID})
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr, "replaced_by": newID})
 }
 
-func errorResponse(code, msg string) gin.H {
-	return gin.H{
-		"error": gin.H{
-			"code":    code,
-			"message": msg,
-		},
+func (s *Server) decline(c *gin.Context) {
+	callerID := services.ActorFromContext(c)
+	if callerID == "" {
+		respondProblem(c, http.StatusUnauthorized, "UNAUTHORIZED", "caller identity is required")
+		return
+	}
+	var req struct {
+		PRID   string `json:"pull_request_id" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
 	}
+
+	pr, newID, err := s.pr.Decline(c, req.PRID, callerID, req.Reason)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr, "replaced_by": newID})
 }
 
-func newBadRequest(err error) gin.H {
-	return gin.H{"error": err.Error()}
+func (s *Server) approve(c *gin.Context) {
+	callerID := services.ActorFromContext(c)
+	if callerID == "" {
+		respondProblem(c, http.StatusUnauthorized, "UNAUTHORIZED", "caller identity is required")
+		return
+	}
+	var req struct {
+		PRID string `json:"pull_request_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	pr, err := s.pr.Approve(c, req.PRID, callerID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr})
+}
+
+func (s *Server) closePR(c *gin.Context) {
+	var req struct {
+		PRID string `json:"pull_request_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if !s.authorizePR(c, req.PRID) {
+		return
+	}
+
+	pr, err := s.pr.Close(c, req.PRID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr})
 }
 
-func newInternal(err error) gin.H {
-	return gin.H{"error": err.Error()}
+func (s *Server) history(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+
+	events, err := s.pr.History(c, prID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"events":          events,
+	})
+}
+
+func (s *Server) assignmentExplain(c *gin.Context) {
+	var req struct {
+		PRID       string `form:"pull_request_id" binding:"required"`
+		ReviewerID string `form:"reviewer_id"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	decisions, err := s.pr.ExplainAssignment(c, req.PRID, req.ReviewerID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": req.PRID,
+		"decisions":       decisions,
+	})
+}
+
+func (s *Server) getPR(c *gin.Context) {
+	pr, err := s.pr.GetPR(c, c.Query("pull_request_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if c.GetHeader("If-None-Match") == etag(pr) {
+		c.Header("ETag", etag(pr))
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	respondPR(c, http.StatusOK, pr, gin.H{"pr": pr})
+}
+
+func (s *Server) searchPRs(c *gin.Context) {
+	var req struct {
+		AuthorID    string     `form:"author_id"`
+		ReviewerID  string     `form:"reviewer_id"`
+		TeamName    string     `form:"team_name"`
+		Status      string     `form:"status" binding:"omitempty,oneof=OPEN MERGED CLOSED"`
+		CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+		CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+		MergedFrom  *time.Time `form:"merged_from" time_format:"2006-01-02T15:04:05Z07:00"`
+		MergedTo    *time.Time `form:"merged_to" time_format:"2006-01-02T15:04:05Z07:00"`
+		Limit       int        `form:"limit" binding:"omitempty,min=1,max=100"`
+		Cursor      string     `form:"cursor"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+
+	page, err := s.pr.Search(c, entities.PRSearchFilter{
+		AuthorID:    req.AuthorID,
+		ReviewerID:  req.ReviewerID,
+		TeamName:    req.TeamName,
+		Status:      entities.PRStatus(req.Status),
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		MergedFrom:  req.MergedFrom,
+		MergedTo:    req.MergedTo,
+		Limit:       req.Limit,
+	}, req.Cursor)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_requests": page.PullRequests,
+		"next_cursor":   page.NextCursor,
+	})
 }
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+const (
+	// streamBatch is how many events a stream reads from the log at once.
+	streamBatch = 100
+	// streamKeepAlive is the period of keep-alive comments. Each one also
+	// re-reads the log, which picks up events committed by other instances.
+	streamKeepAlive = 15 * time.Second
+	// streamSettlePoll is how often a stream re-reads the log while committed
+	// events wait for concurrent transactions to end.
+	streamSettlePoll = 100 * time.Millisecond
+)
+
+// streamedEvents are the PR log events pushed to dashboards.
+var streamedEvents = []entities.PREventType{
+	entities.PREventCreated,
+	entities.PREventReviewerAssigned,
+	entities.PREventReviewerReplaced,
+	entities.PREventMerged,
+}
+
+// streamEvents serves the organization's PR log as server-sent events. The
+// event id is the id in the log and events are sent in id order once they
+// are settled, so a client that reconnects with Last-Event-ID receives
+// everything it missed; a new client starts after the newest settled event.
+func (s *Server) streamEvents(c *gin.Context) {
+	var req struct {
+		TeamName    string `form:"team_name"`
+		UserID      string `form:"user_id"`
+		LastEventID string `form:"last_event_id"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		badRequest(c, err)
+		return
+	}
+	if h := c.GetHeader("Last-Event-ID"); h != "" {
+		req.LastEventID = h
+	}
+
+	// Subscribe before reading the log so that no commit falls in between.
+	wake, cancel := s.events.Subscribe(principal(c).OrgID)
+	defer cancel()
+
+	filter := entities.PREventFilter{
+		Types:    streamedEvents,
+		TeamName: req.TeamName,
+		UserID:   req.UserID,
+		Limit:    streamBatch,
+	}
+	if req.LastEventID != "" {
+		id, err := strconv.ParseInt(req.LastEventID, 10, 64)
+		if err != nil || id < 0 {
+			invalidParam(c, "Last-Event-ID", "must be a non-negative integer")
+			return
+		}
+		filter.AfterID = id
+	} else {
+		id, err := s.pr.LastEventID(c)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		filter.AfterID = id
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		pending, err := s.sendEvents(c, &filter)
+		if err != nil {
+			if c.Request.Context().Err() == nil {
+				log.Printf("request %s %s %s failed: %v", c.GetString(correlationKey), c.Request.Method, c.Request.URL.Path, err)
+			}
+			return
+		}
+		// Pending events settle when other transactions end, which does not
+		// necessarily wake the stream.
+		var settle <-chan time.Time
+		if pending {
+			settle = time.After(streamSettlePoll)
+		}
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-wake:
+		case <-settle:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// sendEvents writes the settled events after f.AfterID and advances it. It
+// reports whether committed events are still waiting to settle.
+func (s *Server) sendEvents(c *gin.Context, f *entities.PREventFilter) (bool, error) {
+	for {
+		page, err := s.pr.EventsSince(c, *f)
+		if err != nil {
+			return false, err
+		}
+		for _, e := range page.Events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return false, err
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.EventID, e.Type, data); err != nil {
+				return false, err
+			}
+			f.AfterID = e.EventID
+		}
+		if len(page.Events) > 0 {
+			c.Writer.Flush()
+		}
+		if len(page.Events) < f.Limit {
+			// Everything up to the settled id has been sent or filtered out.
+			f.AfterID = page.SettledID
+			return page.Pending, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+// ExclusionReason tells why a team member could not be picked.
+type ExclusionReason string
+
+const (
+	ExclusionAuthor     ExclusionReason = "author"
+	ExclusionInactive   ExclusionReason = "inactive"
+	ExclusionAssigned   ExclusionReason = "already_assigned"
+	ExclusionDeclined   ExclusionReason = "declined"
+	ExclusionReplaced   ExclusionReason = "replaced"
+	ExclusionAtCapacity ExclusionReason = "at_capacity"
+	// ExclusionIneligible covers members rejected by the slot's rules: not a
+	// lead for the lead slot, not a junior for the shadow slot, or the wrong
+	// seniority for the mentorship rule.
+	ExclusionIneligible ExclusionReason = "ineligible"
+	// ExclusionNoMentor marks a junior who was picked and then left out
+	// because no senior could review alongside them.
+	ExclusionNoMentor ExclusionReason = "no_mentor"
+)
+
+// CandidateScore is a member that could have been picked. Score is the review
+// weight multiplied by the strategy factor; Probability is its share of the
+// pool.
+type CandidateScore struct {
+	UserID      string
+	TeamName    string
+	Weight      float64
+	Factor      float64
+	Score       float64
+	Probability float64
+}
+
+type ExcludedCandidate struct {
+	UserID   string
+	TeamName string
+	Reason   ExclusionReason
+}
+
+// AssignmentDecision explains one reviewer pick: who could have been chosen
+// with which chances, and who was left out and why. OverCapacity is set when
+// the pick ignored capacity limits because of the team's overload policy.
+type AssignmentDecision struct {
+	DecisionID         int64
+	PRID               string
+	ReviewerID         string
+	Slot               ReviewerSlot
+	Strategy           SelectionStrategy
+	ReplacedReviewerID string
+	OverCapacity       bool
+	Candidates         []CandidateScore
+	Excluded           []ExcludedCandidate
+	CreatedAt          time.Time
+}
+
+// AssignmentPreview shows who CreatePR would pick for a PR by AuthorID without
+// creating it. Ranking lists every candidate seen, best score first.
+type AssignmentPreview struct {
+	AuthorID   string
+	TeamName   string
+	Strategy   SelectionStrategy
+	Reviewers  []ReviewerAssignment
+	Queued     bool
+	Overloaded bool
+	Ranking    []CandidateScore
+	Decisions  []AssignmentDecision
+}
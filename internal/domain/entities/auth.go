@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	// RoleTeamAdmin manages only the teams listed in the principal's scope.
+	RoleTeamAdmin Role = "team-admin"
+	RoleMember    Role = "member"
+)
+
+// Principal is the authenticated caller. UserID is empty for service tokens
+// that do not act as a particular user. Roles apply within OrgID only.
+type Principal struct {
+	OrgID  string
+	UserID string
+	Role   Role
+	Teams  []string
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// ManagesTeam reports whether the principal may administer the team.
+func (p Principal) ManagesTeam(team string) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	if p.Role != RoleTeamAdmin {
+		return false
+	}
+	for _, t := range p.Teams {
+		if t == team {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken describes an issued token. Only a hash of the secret is stored.
+type APIToken struct {
+	TokenID    int64
+	OrgID      string
+	Name       string
+	UserID     string
+	Role       Role
+	Teams      []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// Principal returns the caller a valid token authenticates.
+func (t APIToken) Principal() Principal {
+	return Principal{OrgID: t.OrgID, UserID: t.UserID, Role: t.Role, Teams: t.Teams}
+}
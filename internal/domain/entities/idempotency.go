@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// IdempotencyKey is the stored outcome of a request sent with an
+// Idempotency-Key. RequestHash identifies the request the key was first used
+// with; StatusCode is 0 while that request is still being processed. An
+// in-flight key is held under the lease LeaseID until LeaseExpiresAt; after
+// that another request may claim the key again.
+type IdempotencyKey struct {
+	Key            string
+	RequestHash    string
+	StatusCode     int
+	ContentType    string
+	Body           []byte
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	LeaseID        string
+	LeaseExpiresAt time.Time
+}
+
+// Completed reports whether the response of the request has been stored.
+func (k IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
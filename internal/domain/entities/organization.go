@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// DefaultOrgID is the organization that data created before multi-tenancy
+// belongs to.
+const DefaultOrgID = "default"
+
+// Organization is a tenant. Teams, users, pull requests and API tokens each
+// belong to exactly one organization and are invisible to the others.
+type Organization struct {
+	OrgID     string
+	Name      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+type PREventType string
+
+const (
+	PREventCreated          PREventType = "created"
+	PREventReviewerAssigned PREventType = "reviewer_assigned"
+	PREventReviewerReplaced PREventType = "reviewer_replaced"
+	PREventReviewed         PREventType = "reviewed"
+	PREventMerged           PREventType = "merged"
+	PREventClosed           PREventType = "closed"
+)
+
+type PREvent struct {
+	EventID       int64       `db:"event_id"`
+	PRID          string      `db:"pr_id"`
+	Type          PREventType `db:"event_type"`
+	OldReviewerID string      `db:"old_reviewer_id"`
+	NewReviewerID string      `db:"new_reviewer_id"`
+	ActorID       string      `db:"actor_id"`
+	Reason        string      `db:"reason"`
+	CreatedAt     time.Time   `db:"created_at"`
+}
+
+// PREventFilter selects events of the organization's log that follow
+// AfterID, oldest first. TeamName matches events of PRs whose author belongs
+// to the team; UserID matches events the user authored, acted in or was
+// assigned or replaced by.
+type PREventFilter struct {
+	AfterID  int64
+	Types    []PREventType
+	TeamName string
+	UserID   string
+	Limit    int
+}
+
+// PREventPage is a read of the organization's log after PREventFilter.AfterID.
+// Events are numbered when they are written but may commit in any order, so
+// a read only returns settled events: no event with an id up to SettledID can
+// still appear. Pending reports that later events have committed and will
+// settle once the transactions running alongside them end.
+type PREventPage struct {
+	Events    []PREvent
+	SettledID int64
+	Pending   bool
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+// PRCursor is the keyset position of a pull request in (created_at, pr_id) order.
+type PRCursor struct {
+	CreatedAt time.Time
+	PRID      string
+}
+
+type PRSearchFilter struct {
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Status      PRStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	After       *PRCursor
+	Limit       int
+}
+
+type PRPage struct {
+	PullRequests []PullRequest
+	NextCursor   string
+}
+
+// ReviewListFilter narrows the pull requests assigned to a single reviewer.
+// OnlyPending drops the ones the reviewer has already approved. All returns
+// every matching pull request on one page and ignores Limit.
+type ReviewListFilter struct {
+	ReviewerID  string
+	Status      PRStatus
+	OnlyPending bool
+	After       *PRCursor
+	Limit       int
+	All         bool
+}
+
+type ReviewCounts struct {
+	Total    int
+	Open     int
+	Merged   int
+	Closed   int
+	Approved int
+	// Declined counts the PRs the reviewer declined and was replaced on.
+	Declined int
+}
+
+type ReviewPage struct {
+	PRPage
+	Counts ReviewCounts
+}
@@ -7,8 +7,31 @@ type PRStatus string
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+// ReviewerSlot tells why a reviewer was assigned. The lead slot is only used
+// when the team requires a lead to review the PR; a shadow is an extra junior
+// whose approval never satisfies merge policies.
+type ReviewerSlot string
+
+const (
+	ReviewerSlotRegular ReviewerSlot = "regular"
+	ReviewerSlotLead    ReviewerSlot = "lead"
+	ReviewerSlotShadow  ReviewerSlot = "shadow"
+)
+
+type ReviewerAssignment struct {
+	ReviewerID string       `db:"reviewer_id"`
+	Slot       ReviewerSlot `db:"slot"`
+}
+
+// PRSize is the size hint given by the PR author.
+type PRSize struct {
+	LinesChanged int `db:"lines_changed"`
+	FilesChanged int `db:"files_changed"`
+}
+
 type PullRequest struct {
 	PRID      string     `db:"pr_id"`
 	Name      string     `db:"pr_name"`
@@ -16,5 +39,15 @@ type PullRequest struct {
 	Status    PRStatus   `db:"status"`
 	CreatedAt time.Time  `db:"created_at"`
 	MergedAt  *time.Time `db:"merged_at"`
-	Reviewers []string
+	PRSize
+	// Overloaded is set when reviewers were assigned beyond their capacity;
+	// Queued while reviewer slots wait for capacity to free up.
+	Overloaded bool `db:"overloaded"`
+	Queued     bool `db:"queued"`
+	// Version grows with every change of the PR or of its reviewers.
+	Version         int64 `db:"version"`
+	Reviewers       []string
+	LeadReviewers   []string
+	ShadowReviewers []string
+	Approvals       []string
 }
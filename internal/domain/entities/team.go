@@ -1,6 +1,123 @@
 package entities
 
+import "time"
+
+const (
+	DefaultReviewersCount    = 2
+	DefaultPairingWindowDays = 30
+	DefaultPairingDecay      = 0.5
+)
+
+// OverloadPolicy decides what happens when every reviewer candidate is at capacity.
+type OverloadPolicy string
+
+const (
+	// OverloadAssign picks reviewers regardless of capacity and flags the PR.
+	OverloadAssign OverloadPolicy = "assign"
+	// OverloadQueue leaves the slots empty until a review completes.
+	OverloadQueue OverloadPolicy = "queue"
+	// OverloadFail rejects the assignment.
+	OverloadFail OverloadPolicy = "fail"
+)
+
+// SelectionStrategy decides how candidates are weighted when reviewers are picked.
+type SelectionStrategy string
+
+const (
+	// StrategyRandom draws candidates by their review weight alone.
+	StrategyRandom SelectionStrategy = "random"
+	// StrategySpreadKnowledge additionally down-weights reviewers who were
+	// recently assigned to the same author.
+	StrategySpreadKnowledge SelectionStrategy = "spread_knowledge"
+)
+
+type TeamSettings struct {
+	ReviewersCount int `db:"reviewers_count"`
+	// EscalateToParent lets reviewer selection fill missing slots from the parent team.
+	EscalateToParent bool `db:"escalate_to_parent"`
+	// PRs with more changed lines or files than these limits need a lead
+	// among their reviewers. Zero disables the limit.
+	LeadReviewMinLines int `db:"lead_review_min_lines"`
+	LeadReviewMinFiles int `db:"lead_review_min_files"`
+	// MentorshipRequired forbids a junior reviewer unless another reviewer is senior.
+	MentorshipRequired bool `db:"mentorship_required"`
+	// ShadowJuniors adds an extra junior reviewer whose approval does not count.
+	ShadowJuniors bool `db:"shadow_juniors"`
+	// With the spread knowledge strategy every assignment to the same author
+	// within the last PairingWindowDays multiplies a candidate's weight by
+	// PairingDecay.
+	Strategy          SelectionStrategy `db:"selection_strategy"`
+	PairingWindowDays int               `db:"pairing_window_days"`
+	PairingDecay      float64           `db:"pairing_decay"`
+	// MaxOpenReviews is the default limit of pending reviews per member; zero
+	// means unlimited. OverloadPolicy applies when every candidate is at it.
+	MaxOpenReviews int            `db:"max_open_reviews"`
+	OverloadPolicy OverloadPolicy `db:"overload_policy"`
+}
+
+// PairingWindow is the period of assignment history the spread knowledge
+// strategy and the pairing matrix look at.
+func (s TeamSettings) PairingWindow() time.Duration {
+	return time.Duration(s.PairingWindowDays) * 24 * time.Hour
+}
+
+// RequiresLead reports whether a PR of the given size must be reviewed by a lead.
+func (s TeamSettings) RequiresLead(size PRSize) bool {
+	return (s.LeadReviewMinLines > 0 && size.LinesChanged > s.LeadReviewMinLines) ||
+		(s.LeadReviewMinFiles > 0 && size.FilesChanged > s.LeadReviewMinFiles)
+}
+
 type Team struct {
-	TeamName string `db:"team_name"`
-	Members  []User `db:"-"`
+	TeamName   string       `db:"team_name"`
+	ParentTeam string       `db:"parent_team"`
+	Settings   TeamSettings `db:"-"`
+	Members    []TeamMember `db:"-"`
+}
+
+// TeamUpdate describes a partial change to a team. A nil Settings keeps the
+// current settings, a nil ParentTeam keeps the parent and an empty one detaches
+// the team from its parent. MoveExistingMembers lets added primary members
+// move their primary membership here from another team.
+type TeamUpdate struct {
+	AddMembers          []TeamMember
+	RemoveMembers       []string
+	Settings            *TeamSettings
+	ParentTeam          *string
+	MoveExistingMembers bool
+}
+
+// TeamNode is a team together with its sub-teams.
+type TeamNode struct {
+	TeamName string
+	Children []TeamNode
+}
+
+type TeamStatsCounters struct {
+	Members           int
+	ActiveMembers     int
+	OpenPRs           int
+	MergedPRs         int
+	ClosedPRs         int
+	ReviewAssignments int
+	// Declines counts the reviews the members declined.
+	Declines int
+}
+
+// ReviewPairing counts how often ReviewerID was assigned to PRs of AuthorID.
+type ReviewPairing struct {
+	AuthorID   string
+	ReviewerID string
+	Count      int
+}
+
+// TeamStats holds the counters of the team alone and rolled up over all of its
+// descendants, plus the author-reviewer pairings of the team's members within
+// its pairing window.
+type TeamStats struct {
+	TeamName          string
+	Subteams          []string
+	Own               TeamStatsCounters
+	Rollup            TeamStatsCounters
+	PairingWindowDays int
+	Pairings          []ReviewPairing
 }
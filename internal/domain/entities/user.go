@@ -1,8 +1,39 @@
 package entities
 
+type MemberRole string
+
+const (
+	MemberRoleMember MemberRole = "member"
+	MemberRoleLead   MemberRole = "lead"
+)
+
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+)
+
+// User describes a person. TeamName is the primary team, kept for clients that
+// predate multi-team membership.
 type User struct {
-	UserID   string `db:"user_id"`
-	Username string `db:"username"`
-	IsActive bool   `db:"is_active"`
-	TeamName string `db:"team_name"`
+	UserID    string    `db:"user_id"`
+	Username  string    `db:"username"`
+	IsActive  bool      `db:"is_active"`
+	TeamName  string    `db:"team_name"`
+	Seniority Seniority `db:"seniority"`
+	// MaxOpenReviews caps the user's pending reviews; nil uses the team default.
+	MaxOpenReviews *int `db:"max_open_reviews"`
+}
+
+// TeamMember is a user seen through their membership in one particular team.
+type TeamMember struct {
+	User
+	IsPrimary    bool       `db:"is_primary"`
+	ReviewWeight float64    `db:"review_weight"`
+	Role         MemberRole `db:"role"`
+	// OpenReviews counts pending reviews on open PRs. It is only filled when
+	// listing reviewer candidates.
+	OpenReviews int `db:"-"`
 }
@@ -1,16 +1,58 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrPRNotFound        = errors.New("pull request not found")
-	ErrUserNotFound      = errors.New("user not found")
-	ErrTeamNotFound      = errors.New("team not found")
-	ErrPRAlreadyMerged   = errors.New("pull request already merged")
-	ErrReviewerNotInTeam = errors.New("reviewer is not in expected team")
-	ErrReviewerInactive  = errors.New("reviewer is inactive")
-	ErrNoCandidates      = errors.New("no active candidates in team")
-	ErrNoSuchReviewer    = errors.New("this reviewer is not assigned to PR")
-	ErrPRExists          = errors.New("pull request already exists")
-	ErrTeamExists        = errors.New("team already exists")
+	ErrPRNotFound           = errors.New("pull request not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrTeamNotFound         = errors.New("team not found")
+	ErrPRAlreadyMerged      = errors.New("pull request already merged")
+	ErrPRClosed             = errors.New("pull request is closed")
+	ErrReviewerNotInTeam    = errors.New("reviewer is not in expected team")
+	ErrReviewerInactive     = errors.New("reviewer is inactive")
+	ErrNoCandidates         = errors.New("no active candidates in team")
+	ErrNoSuchReviewer       = errors.New("this reviewer is not assigned to PR")
+	ErrPRExists             = errors.New("pull request already exists")
+	ErrTeamExists           = errors.New("team already exists")
+	ErrTeamHasOpenPRs       = errors.New("team members have open pull requests")
+	ErrInvalidTargetTeam    = errors.New("target team must differ from the deleted team")
+	ErrParentNotFound       = errors.New("parent team not found")
+	ErrTeamCycle            = errors.New("team hierarchy would contain a cycle")
+	ErrNoLeadCandidate      = errors.New("no active lead available to review")
+	ErrLeadApprovalRequired = errors.New("pull request requires approval from a lead")
+	ErrInvalidCursor        = errors.New("invalid pagination cursor")
+	ErrMemberConflict       = errors.New("members already belong to another team")
+	ErrReviewersAtCapacity  = errors.New("every candidate reviewer is at capacity")
+	ErrUnauthenticated      = errors.New("missing or invalid credentials")
+	ErrForbidden            = errors.New("not allowed for this principal")
+	ErrTokenNotFound        = errors.New("api token not found")
+	ErrIdempotencyKeyInUse  = errors.New("a request with this idempotency key is still in progress")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	ErrVersionMismatch      = errors.New("pull request has changed since the given version")
 )
+
+// MemberConflict names a user that already belongs to a different team.
+type MemberConflict struct {
+	UserID   string
+	TeamName string
+}
+
+// MemberConflictError lists every conflicting member; it matches ErrMemberConflict.
+type MemberConflictError struct {
+	Conflicts []MemberConflict
+}
+
+func (e *MemberConflictError) Error() string {
+	ids := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		ids = append(ids, c.UserID+" ("+c.TeamName+")")
+	}
+	return ErrMemberConflict.Error() + ": " + strings.Join(ids, ", ")
+}
+
+func (e *MemberConflictError) Is(target error) bool {
+	return target == ErrMemberConflict
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type AssignmentDecisionRepository interface {
+	Append(ctx context.Context, d *entities.AssignmentDecision) error
+	ListByPR(ctx context.Context, prID string) ([]entities.AssignmentDecision, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores k as in flight unless the organization already has the
+	// key, in which case it reports false. Expired keys and in-flight keys
+	// whose lease ran out are dropped first.
+	Reserve(ctx context.Context, k *entities.IdempotencyKey) (bool, error)
+	// Get returns nil when the key does not exist or has expired.
+	Get(ctx context.Context, key string) (*entities.IdempotencyKey, error)
+	// Complete and Delete only touch the key while it is still held under the
+	// lease of k, so a request that outlived its lease cannot overwrite or
+	// release a later claim.
+	Complete(ctx context.Context, k *entities.IdempotencyKey) error
+	Delete(ctx context.Context, k *entities.IdempotencyKey) error
+}
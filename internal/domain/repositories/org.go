@@ -0,0 +1,18 @@
+package repositories
+
+import "context"
+
+type orgKey struct{}
+
+// WithOrg returns a copy of ctx scoped to the organization. Every repository
+// query only sees and changes data of the organization in its context.
+func WithOrg(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// OrgFromContext returns the organization of ctx, or an empty string, which
+// matches no data.
+func OrgFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(orgKey{}).(string)
+	return id
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type OrganizationRepository interface {
+	// Ensure creates the organization unless it already exists.
+	Ensure(ctx context.Context, org *entities.Organization) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type PREventRepository interface {
+	Append(ctx context.Context, e *entities.PREvent) error
+	ListByPR(ctx context.Context, prID string) ([]entities.PREvent, error)
+	// ListSince pages through the settled part of the organization's log in
+	// event id order.
+	ListSince(ctx context.Context, f entities.PREventFilter) (*entities.PREventPage, error)
+	// SettledID returns the id of the organization's newest settled event, 0
+	// if none.
+	SettledID(ctx context.Context) (int64, error)
+}
@@ -2,14 +2,28 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
 )
 
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr *entities.PullRequest) error
 	GetByID(ctx context.Context, id string) (*entities.PullRequest, error)
-	ListByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequest, error)
-	AssignReviewers(ctx context.Context, prID string, reviewers []string) error
+	// GetForUpdate is GetByID that also locks the PR until the transaction ends.
+	GetForUpdate(ctx context.Context, id string) (*entities.PullRequest, error)
+	ListByReviewer(ctx context.Context, filter entities.ReviewListFilter) ([]entities.PullRequest, error)
+	CountByReviewer(ctx context.Context, reviewerID string) (entities.ReviewCounts, error)
+	Search(ctx context.Context, filter entities.PRSearchFilter) ([]entities.PullRequest, error)
+	AssignReviewers(ctx context.Context, prID string, reviewers []entities.ReviewerAssignment) error
 	ReplaceReviewer(ctx context.Context, prID string, oldID, newID string) error
 	MarkMerged(ctx context.Context, prID string) error
+	MarkClosed(ctx context.Context, prID string) error
+	MarkApproved(ctx context.Context, prID, reviewerID string) error
+	RecordDecline(ctx context.Context, prID, reviewerID, reason string) error
+	ListDeclined(ctx context.Context, prID string) ([]string, error)
+	MarkOverloaded(ctx context.Context, prID string) error
+	SetQueued(ctx context.Context, prID string, queued bool) error
+	LockQueued(ctx context.Context) ([]string, error)
+	CountPairings(ctx context.Context, authorID string, since time.Time) (map[string]int, error)
 }
@@ -2,10 +2,27 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
 )
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *entities.Team) error
 	GetByName(ctx context.Context, name string) (*entities.Team, error)
+	UpdateSettings(ctx context.Context, name string, settings entities.TeamSettings) error
+	Rename(ctx context.Context, oldName, newName string) error
+	Delete(ctx context.Context, name string) error
+	HasOpenPRs(ctx context.Context, name string) (bool, error)
+	MoveMembers(ctx context.Context, from, to string) error
+	SetParent(ctx context.Context, name, parent string) error
+	// LockHierarchy locks name, parent and the ancestors of parent until the
+	// transaction ends, so concurrent reparenting cannot close a cycle.
+	LockHierarchy(ctx context.Context, name, parent string) error
+	ReparentChildren(ctx context.Context, name, newParent string) error
+	Ancestors(ctx context.Context, name string) ([]string, error)
+	Descendants(ctx context.Context, name string) ([]string, error)
+	List(ctx context.Context) ([]entities.Team, error)
+	Stats(ctx context.Context, teams []string) (entities.TeamStatsCounters, error)
+	Pairings(ctx context.Context, team string, since time.Time) ([]entities.ReviewPairing, error)
 }
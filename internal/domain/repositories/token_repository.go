@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+type TokenRepository interface {
+	Create(ctx context.Context, t *entities.APIToken, hash string) error
+	// GetByHash returns the token with the given secret hash, revoked or not.
+	GetByHash(ctx context.Context, hash string) (*entities.APIToken, error)
+	List(ctx context.Context) ([]entities.APIToken, error)
+	// Revoke reports false when no active token has the id.
+	Revoke(ctx context.Context, id int64) (bool, error)
+	TouchLastUsed(ctx context.Context, id int64) error
+}
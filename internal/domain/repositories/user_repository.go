@@ -10,5 +10,10 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*entities.User, error)
 	ListByTeam(ctx context.Context, team string) ([]entities.User, error)
 	ListActiveByTeam(ctx context.Context, team string) ([]entities.User, error)
+	ListCandidates(ctx context.Context, team string) ([]entities.TeamMember, error)
 	SetActive(ctx context.Context, id string, active bool) error
+	SetMaxOpenReviews(ctx context.Context, id string, limit *int) error
+	SetSeniority(ctx context.Context, id string, seniority entities.Seniority) error
+	AddMembership(ctx context.Context, team string, m entities.TeamMember) error
+	RemoveFromTeam(ctx context.Context, id, team string) error
 }
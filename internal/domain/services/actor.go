@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+)
+
+type actorKey struct{}
+
+type principalKey struct{}
+
+type versionKey struct{}
+
+// WithActor returns a copy of ctx carrying the id of the user performing the call.
+func WithActor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the acting user id, or an empty string for anonymous calls.
+func ActorFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(actorKey{}).(string)
+	return id
+}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated caller, who
+// also becomes the actor, and scopes it to the caller's organization.
+func WithPrincipal(ctx context.Context, p entities.Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, p)
+	ctx = repositories.WithOrg(ctx, p.OrgID)
+	if p.UserID != "" {
+		ctx = WithActor(ctx, p.UserID)
+	}
+	return ctx
+}
+
+// PrincipalFromContext returns the authenticated caller, if any.
+func PrincipalFromContext(ctx context.Context) (entities.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(entities.Principal)
+	return p, ok
+}
+
+// WithExpectedVersion returns a copy of ctx that makes PR mutations fail with
+// ErrVersionMismatch unless the PR is still at the given version.
+func WithExpectedVersion(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, versionKey{}, version)
+}
+
+// ExpectedVersionFromContext returns the version set by WithExpectedVersion.
+func ExpectedVersionFromContext(ctx context.Context) (int64, bool) {
+	v, ok := ctx.Value(versionKey{}).(int64)
+	return v, ok
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// encodeCursor turns the last pull request of a page into an opaque token.
+func encodeCursor(pr entities.PullRequest) string {
+	raw := pr.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + pr.PRID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (*entities.PRCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, errors.ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+	return &entities.PRCursor{CreatedAt: createdAt, PRID: id}, nil
+}
+
+func clampPageSize(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+type idempotencyService struct {
+	keys  repositories.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotencyService keeps every key for ttl after its first use. A
+// request holds its key for at most lease; a request that crashed or hung
+// longer no longer blocks retries with the key.
+func NewIdempotencyService(keys repositories.IdempotencyRepository, ttl, lease time.Duration) interfaces.IdempotencyService {
+	return &idempotencyService{keys: keys, ttl: ttl, lease: lease}
+}
+
+// Begin claims the key with an insert that only one of several concurrent
+// requests wins. The others see the winner's row: they get its response once
+// it is stored and ErrIdempotencyKeyInUse until then, or until its lease runs
+// out and the key can be claimed again.
+func (s *idempotencyService) Begin(ctx context.Context, key, requestHash string) (*entities.IdempotencyKey, error) {
+	// A claim abandoned or expired between the insert and the read frees the
+	// key again; retry the claim a few times before giving up.
+	for attempt := 0; attempt < 3; attempt++ {
+		leaseID, err := newLeaseID()
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		claim := &entities.IdempotencyKey{
+			Key:            key,
+			RequestHash:    requestHash,
+			ExpiresAt:      now.Add(s.ttl),
+			LeaseID:        leaseID,
+			LeaseExpiresAt: now.Add(s.lease),
+		}
+		claimed, err := s.keys.Reserve(ctx, claim)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return claim, nil
+		}
+
+		existing, err := s.keys.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			continue
+		}
+		if existing.RequestHash != requestHash {
+			return nil, errors.ErrIdempotencyKeyReused
+		}
+		if !existing.Completed() {
+			if !existing.LeaseExpiresAt.After(time.Now()) {
+				continue
+			}
+			return nil, errors.ErrIdempotencyKeyInUse
+		}
+		return existing, nil
+	}
+	return nil, errors.ErrIdempotencyKeyInUse
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, k *entities.IdempotencyKey) error {
+	return s.keys.Complete(ctx, k)
+}
+
+func (s *idempotencyService) Abandon(ctx context.Context, k *entities.IdempotencyKey) error {
+	return s.keys.Delete(ctx, k)
+}
+
+func newLeaseID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+// Authenticator turns a bearer credential into the calling principal.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*entities.Principal, error)
+}
+
+type AuthService interface {
+	Authenticator
+	CreateToken(ctx context.Context, t *entities.APIToken) (string, error)
+	EnsureToken(ctx context.Context, t *entities.APIToken, secret string) error
+	ListTokens(ctx context.Context) ([]entities.APIToken, error)
+	RevokeToken(ctx context.Context, id int64) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+// EventNotifier is told when new events were committed to an organization's
+// PR log. Notify must not block.
+type EventNotifier interface {
+	Notify(orgID string)
+}
+
+// EventSubscriber wakes stream readers of an organization's PR log. The
+// channel receives a value after one or more Notify calls; cancel releases
+// the subscription.
+type EventSubscriber interface {
+	Subscribe(orgID string) (wake <-chan struct{}, cancel func())
+}
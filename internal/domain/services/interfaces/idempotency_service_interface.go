@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+// IdempotencyService remembers the responses of requests sent with an
+// idempotency key so that retries replay them instead of running again.
+type IdempotencyService interface {
+	// Begin claims the key for the request with the given hash. It returns the
+	// stored response when the request already completed, and otherwise the
+	// in-flight claim the caller should process the request under.
+	Begin(ctx context.Context, key, requestHash string) (*entities.IdempotencyKey, error)
+	// Complete stores the response of a claim.
+	Complete(ctx context.Context, k *entities.IdempotencyKey) error
+	// Abandon releases a claim without a response, so that it can be retried.
+	Abandon(ctx context.Context, k *entities.IdempotencyKey) error
+}
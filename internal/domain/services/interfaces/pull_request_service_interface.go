@@ -6,8 +6,18 @@ import (
 )
 
 type PRService interface {
-	CreatePR(ctx context.Context, prID, prName, authorID string) (*entities.PullRequest, error)
+	CreatePR(ctx context.Context, prID, prName, authorID string, size entities.PRSize) (*entities.PullRequest, error)
+	PreviewAssignment(ctx context.Context, prID, authorID string, size entities.PRSize) (*entities.AssignmentPreview, error)
 	ReplaceReviewer(ctx context.Context, prID, oldReviewerID string) (*entities.PullRequest, string, error)
+	Decline(ctx context.Context, prID, reviewerID, reason string) (*entities.PullRequest, string, error)
+	Approve(ctx context.Context, prID, reviewerID string) (*entities.PullRequest, error)
 	Merge(ctx context.Context, prID string) (*entities.PullRequest, error)
-	ListByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequest, error)
+	Close(ctx context.Context, prID string) (*entities.PullRequest, error)
+	History(ctx context.Context, prID string) ([]entities.PREvent, error)
+	EventsSince(ctx context.Context, f entities.PREventFilter) (*entities.PREventPage, error)
+	LastEventID(ctx context.Context) (int64, error)
+	ExplainAssignment(ctx context.Context, prID, reviewerID string) ([]entities.AssignmentDecision, error)
+	GetPR(ctx context.Context, prID string) (*entities.PullRequest, error)
+	Search(ctx context.Context, filter entities.PRSearchFilter, cursor string) (*entities.PRPage, error)
+	ListByReviewer(ctx context.Context, filter entities.ReviewListFilter, cursor string) (*entities.ReviewPage, error)
 }
@@ -7,6 +7,11 @@ import (
 )
 
 type TeamService interface {
-	CreateTeam(ctx context.Context, team *entities.Team) (*entities.Team, error)
+	CreateTeam(ctx context.Context, team *entities.Team, moveExisting bool) (*entities.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*entities.Team, error)
+	UpdateTeam(ctx context.Context, teamName string, upd entities.TeamUpdate) (*entities.Team, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (*entities.Team, error)
+	DeleteTeam(ctx context.Context, teamName, targetTeam string) error
+	TeamTree(ctx context.Context, root string) ([]entities.TeamNode, error)
+	GetStats(ctx context.Context, teamName string) (*entities.TeamStats, error)
 }
@@ -7,6 +7,8 @@ import (
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*entities.User, error)
+	SetSeniority(ctx context.Context, userID string, seniority entities.Seniority) (*entities.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*entities.User, error)
 	GetByID(ctx context.Context, userID string) (*entities.User, error)
 	ListByTeam(ctx context.Context, teamName string) ([]entities.User, error)
 	ListActiveByTeam(ctx context.Context, teamName string) ([]entities.User, error)
@@ -2,53 +2,91 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
+
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
 	"github.com/f4ke-n0name/avito/internal/domain/errors"
 	"github.com/f4ke-n0name/avito/internal/domain/repositories"
 	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
-	"github.com/jackc/pgconn"
-	"math/rand"
-	"time"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type prService struct {
-	users repositories.UserRepository
-	teams repositories.TeamRepository
-	prs   repositories.PullRequestRepository
+	users     repositories.UserRepository
+	teams     repositories.TeamRepository
+	prs       repositories.PullRequestRepository
+	events    repositories.PREventRepository
+	decisions repositories.AssignmentDecisionRepository
+	random    RandomSource
+	notifier  interfaces.EventNotifier
 
-	withTx func(ctx context.Context, fn func(txCtx context.Context) error) error
+	withTx     func(ctx context.Context, fn func(txCtx context.Context) error) error
+	readOnlyTx func(ctx context.Context, fn func(txCtx context.Context) error) error
 }
 
-var rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
-
 func NewPRService(
 	users repositories.UserRepository,
 	teams repositories.TeamRepository,
 	prs repositories.PullRequestRepository,
+	events repositories.PREventRepository,
+	decisions repositories.AssignmentDecisionRepository,
+	random RandomSource,
+	notifier interfaces.EventNotifier,
 	withTx func(ctx context.Context, fn func(txCtx context.Context) error) error,
+	readOnlyTx func(ctx context.Context, fn func(txCtx context.Context) error) error,
 ) interfaces.PRService {
-	return &prService{
-		users:  users,
-		teams:  teams,
-		prs:    prs,
-		withTx: withTx,
+	s := &prService{
+		users:      users,
+		teams:      teams,
+		prs:        prs,
+		events:     events,
+		decisions:  decisions,
+		random:     random,
+		notifier:   notifier,
+		readOnlyTx: readOnlyTx,
+	}
+	s.withTx = s.logged(withTx)
+	return s
+}
+
+// logged wraps the write transactions of the service so that event streams
+// are woken once a transaction has committed.
+func (s *prService) logged(
+	withTx func(ctx context.Context, fn func(txCtx context.Context) error) error,
+) func(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return func(ctx context.Context, fn func(txCtx context.Context) error) error {
+		err := withTx(ctx, fn)
+		if err == nil {
+			s.notifier.Notify(repositories.OrgFromContext(ctx))
+		}
+		return err
 	}
 }
 
-func (s *prService) CreatePR(ctx context.Context, prID, prName, authorID string) (*entities.PullRequest, error) {
-	author, err := s.users.GetByID(ctx, authorID)
-	if err != nil || author == nil {
-		return nil, errors.ErrUserNotFound
+// CreatePR creates the PR and assigns its reviewers. An empty authorID
+// defaults to the caller.
+func (s *prService) CreatePR(ctx context.Context, prID, prName, authorID string, size entities.PRSize) (*entities.PullRequest, error) {
+	if authorID == "" {
+		authorID = ActorFromContext(ctx)
 	}
 
 	var pr *entities.PullRequest
 
-	err = s.withTx(ctx, func(txCtx context.Context) error {
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		author, err := s.users.GetByID(txCtx, authorID)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			return errors.ErrUserNotFound
+		}
+
 		pr = &entities.PullRequest{
 			PRID:     prID,
 			Name:     prName,
 			AuthorID: authorID,
 			Status:   entities.PRStatusOpen,
+			PRSize:   size,
 		}
 
 		if err := s.prs.Create(txCtx, pr); err != nil {
@@ -57,128 +95,519 @@ func (s *prService) CreatePR(ctx context.Context, prID, prName, authorID string)
 			}
 			return err
 		}
+		if err := s.record(txCtx, entities.PREvent{PRID: prID, Type: entities.PREventCreated, ActorID: authorID}); err != nil {
+			return err
+		}
 
-		candidates, err := s.users.ListActiveByTeam(txCtx, author.TeamName)
+		team, err := s.teams.GetByName(txCtx, author.TeamName)
 		if err != nil {
 			return err
 		}
-
-		var filtered []entities.User
-		for _, u := range candidates {
-			if u.UserID != authorID {
-				filtered = append(filtered, u)
-			}
+		if err := s.fillReviewers(txCtx, team, pr, overloadPolicy(team)); err != nil {
+			return err
 		}
+		pr, err = s.prs.GetByID(txCtx, prID)
+		return err
+	})
 
-		reviewers := pickTwoRandom(filtered)
+	return pr, err
+}
 
-		var ids []string
-		for _, r := range reviewers {
-			ids = append(ids, r.UserID)
+// PreviewAssignment runs the reviewer selection of CreatePR for a PR that is
+// not created, inside a read-only transaction. prID is optional; with a
+// deterministic random source the same id yields the same picks as CreatePR.
+// An empty authorID defaults to the caller.
+func (s *prService) PreviewAssignment(ctx context.Context, prID, authorID string, size entities.PRSize) (*entities.AssignmentPreview, error) {
+	if authorID == "" {
+		authorID = ActorFromContext(ctx)
+	}
+	var preview *entities.AssignmentPreview
+	err := s.readOnlyTx(ctx, func(txCtx context.Context) error {
+		author, err := s.users.GetByID(txCtx, authorID)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			return errors.ErrUserNotFound
 		}
+		team, err := s.teams.GetByName(txCtx, author.TeamName)
+		if err != nil {
+			return err
+		}
+		pr := &entities.PullRequest{PRID: prID, AuthorID: authorID, Status: entities.PRStatusOpen, PRSize: size}
+		plan, err := s.planReviewers(txCtx, team, pr, overloadPolicy(team))
+		if err != nil {
+			return err
+		}
+		preview = &entities.AssignmentPreview{
+			AuthorID:   authorID,
+			TeamName:   author.TeamName,
+			Strategy:   entities.StrategyRandom,
+			Reviewers:  plan.assignments,
+			Queued:     plan.queued,
+			Overloaded: plan.overloaded,
+			Ranking:    rankCandidates(plan.decisions),
+			Decisions:  plan.decisions,
+		}
+		if team != nil && team.Settings.Strategy != "" {
+			preview.Strategy = team.Settings.Strategy
+		}
+		return nil
+	})
+	return preview, err
+}
 
-		if len(ids) > 0 {
-			if err := s.prs.AssignReviewers(txCtx, prID, ids); err != nil {
-				return err
-			}
-			pr.Reviewers = ids
+func (s *prService) ReplaceReviewer(ctx context.Context, prID, oldReviewerID string) (*entities.PullRequest, string, error) {
+	var updated *entities.PullRequest
+	var newID string
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		pr, err := s.lockPR(txCtx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == entities.PRStatusMerged {
+			return errors.ErrPRAlreadyMerged
+		}
+		if pr.Status == entities.PRStatusClosed {
+			return errors.ErrPRClosed
+		}
+		if !containsID(pr.Reviewers, oldReviewerID) {
+			return errors.ErrNoSuchReviewer
 		}
+		oldReviewer, err := s.users.GetByID(txCtx, oldReviewerID)
+		if err != nil {
+			return err
+		}
+		if oldReviewer == nil {
+			return errors.ErrUserNotFound
+		}
+		newID, err = s.pickReplacement(txCtx, pr, oldReviewerID)
+		if err != nil {
+			return err
+		}
+		if err := s.prs.ReplaceReviewer(txCtx, prID, oldReviewerID, newID); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, entities.PREvent{
+			PRID:          prID,
+			Type:          entities.PREventReviewerReplaced,
+			OldReviewerID: oldReviewerID,
+			NewReviewerID: newID,
+		}); err != nil {
+			return err
+		}
+		updated, err = s.prs.GetByID(txCtx, prID)
+		return err
+	})
+	return updated, newID, err
+}
 
-		return nil
+func (s *prService) Decline(ctx context.Context, prID, reviewerID, reason string) (*entities.PullRequest, string, error) {
+	var updated *entities.PullRequest
+	var newID string
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		pr, err := s.lockPR(txCtx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == entities.PRStatusMerged {
+			return errors.ErrPRAlreadyMerged
+		}
+		if pr.Status == entities.PRStatusClosed {
+			return errors.ErrPRClosed
+		}
+		if !containsID(pr.Reviewers, reviewerID) {
+			return errors.ErrNoSuchReviewer
+		}
+		reviewer, err := s.users.GetByID(txCtx, reviewerID)
+		if err != nil {
+			return err
+		}
+		if reviewer == nil {
+			return errors.ErrUserNotFound
+		}
+		if err := s.prs.RecordDecline(txCtx, prID, reviewerID, reason); err != nil {
+			return err
+		}
+		newID, err = s.pickReplacement(txCtx, pr, reviewerID)
+		if err != nil {
+			return err
+		}
+		if err := s.prs.ReplaceReviewer(txCtx, prID, reviewerID, newID); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, entities.PREvent{
+			PRID:          prID,
+			Type:          entities.PREventReviewerReplaced,
+			OldReviewerID: reviewerID,
+			NewReviewerID: newID,
+			ActorID:       reviewerID,
+			Reason:        reason,
+		}); err != nil {
+			return err
+		}
+		updated, err = s.prs.GetByID(txCtx, prID)
+		return err
 	})
+	return updated, newID, err
+}
 
-	return pr, err
+// lockPR reads the PR a mutation is about and locks it until the transaction
+// ends, so that concurrent mutations of the PR run one after another and each
+// sees the changes of the previous one. It fails with ErrVersionMismatch when
+// the caller expects another version.
+func (s *prService) lockPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	pr, err := s.prs.GetForUpdate(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, errors.ErrPRNotFound
+	}
+	if v, ok := ExpectedVersionFromContext(ctx); ok && v != pr.Version {
+		return nil, errors.ErrVersionMismatch
+	}
+	return pr, nil
 }
 
-func (s *prService) ReplaceReviewer(ctx context.Context, prID, oldReviewerID string) (*entities.PullRequest, string, error) {
-	pr, err := s.prs.GetByID(ctx, prID)
-	if err != nil || pr == nil {
-		return nil, "", errors.ErrPRNotFound
+// record appends e to the PR history, attributing it to the caller when no
+// explicit actor is set. It must run inside the transaction of the mutation.
+func (s *prService) record(ctx context.Context, e entities.PREvent) error {
+	if e.ActorID == "" {
+		e.ActorID = ActorFromContext(ctx)
+	}
+	return s.events.Append(ctx, &e)
+}
+
+// pickReplacement chooses a new reviewer for the author's primary team,
+// skipping the author, everyone already assigned and everyone who declined
+// this PR. A reviewer in the lead slot is only replaced by another lead and a
+// shadow by another junior; regular replacements honour the mentorship rule.
+// When only candidates at capacity remain, the assign policy takes one of
+// them and flags the PR; the queue and fail policies reject the replacement,
+// since the old reviewer cannot be released without a successor.
+func (s *prService) pickReplacement(ctx context.Context, pr *entities.PullRequest, oldReviewerID string) (string, error) {
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	if author == nil {
+		return "", errors.ErrUserNotFound
+	}
+	team, err := s.teams.GetByName(ctx, author.TeamName)
+	if err != nil {
+		return "", err
+	}
+	declined, err := s.prs.ListDeclined(ctx, pr.PRID)
+	if err != nil {
+		return "", err
+	}
+	sel, err := s.newSelection(ctx, team, pr, selectionKey(pr.PRID, oldReviewerID))
+	if err != nil {
+		return "", err
 	}
-	if pr.Status == entities.PRStatusMerged {
-		return nil, "", errors.ErrPRAlreadyMerged
+	sel.replaced = oldReviewerID
+	sel.excluded[oldReviewerID] = entities.ExclusionReplaced
+	for _, id := range declined {
+		sel.excluded[id] = entities.ExclusionDeclined
 	}
-	assigned := false
-	for _, r := range pr.Reviewers {
-		if r == oldReviewerID {
-			assigned = true
-			break
+	slot := entities.ReviewerSlotRegular
+	var accept func(entities.TeamMember) bool
+	if containsID(pr.ShadowReviewers, oldReviewerID) {
+		slot, accept = entities.ReviewerSlotShadow, isJunior
+	} else if team != nil {
+		var others []entities.TeamMember
+		for _, id := range pr.Reviewers {
+			if id == oldReviewerID || containsID(pr.ShadowReviewers, id) {
+				continue
+			}
+			u, err := s.users.GetByID(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			if u != nil {
+				others = append(others, entities.TeamMember{User: *u})
+			}
+		}
+		var lead func(entities.TeamMember) bool
+		if containsID(pr.LeadReviewers, oldReviewerID) {
+			slot, lead = entities.ReviewerSlotLead, isLead
+		}
+		accept = allOf(lead, mentorshipFilter(team.Settings, others, 1))
+	} else if containsID(pr.LeadReviewers, oldReviewerID) {
+		slot, accept = entities.ReviewerSlotLead, isLead
+	}
+	picked, err := s.selectReviewers(ctx, team, sel, slot, 1, accept)
+	if err != nil {
+		return "", err
+	}
+	if len(picked) == 0 && sel.capped {
+		if overloadPolicy(team) != entities.OverloadAssign {
+			return "", errors.ErrReviewersAtCapacity
+		}
+		sel.ignoreCapacity = true
+		picked, err = s.selectReviewers(ctx, team, sel, slot, 1, accept)
+		if err != nil {
+			return "", err
+		}
+		if len(picked) > 0 && !pr.Overloaded {
+			if err := s.prs.MarkOverloaded(ctx, pr.PRID); err != nil {
+				return "", err
+			}
 		}
 	}
-	if !assigned {
-		return nil, "", errors.ErrNoSuchReviewer
+	if len(picked) == 0 {
+		return "", errors.ErrNoCandidates
 	}
-	oldReviewer, err := s.users.GetByID(ctx, oldReviewerID)
-	if err != nil || oldReviewer == nil {
-		return nil, "", errors.ErrUserNotFound
+	if err := s.saveDecisions(ctx, sel.decisions); err != nil {
+		return "", err
 	}
+	return picked[0].UserID, nil
+}
+
+func (s *prService) Approve(ctx context.Context, prID, reviewerID string) (*entities.PullRequest, error) {
 	var updated *entities.PullRequest
-	var newID string
-	err = s.withTx(ctx, func(txCtx context.Context) error {
-		candidates, err := s.users.ListActiveByTeam(txCtx, oldReviewer.TeamName)
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		pr, err := s.lockPR(txCtx, prID)
 		if err != nil {
 			return err
 		}
-		var filtered []entities.User
-		for _, u := range candidates {
-			if u.UserID != oldReviewerID {
-				filtered = append(filtered, u)
-			}
+		if pr.Status == entities.PRStatusMerged {
+			return errors.ErrPRAlreadyMerged
 		}
-		if len(filtered) == 0 {
-			return errors.ErrNoCandidates
+		if pr.Status == entities.PRStatusClosed {
+			return errors.ErrPRClosed
 		}
-		newUser := filtered[rnd.Intn(len(filtered))]
-		newID = newUser.UserID
-		if err := s.prs.ReplaceReviewer(txCtx, prID, oldReviewerID, newID); err != nil {
+		if !containsID(pr.Reviewers, reviewerID) {
+			return errors.ErrNoSuchReviewer
+		}
+		if containsID(pr.Approvals, reviewerID) {
+			updated = pr
+			return nil
+		}
+		if err := s.prs.MarkApproved(txCtx, prID, reviewerID); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, entities.PREvent{PRID: prID, Type: entities.PREventReviewed, ActorID: reviewerID}); err != nil {
+			return err
+		}
+		if err := s.assignQueued(txCtx); err != nil {
 			return err
 		}
 		updated, err = s.prs.GetByID(txCtx, prID)
 		return err
 	})
-	return updated, newID, err
+	return updated, err
 }
 
 func (s *prService) Merge(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	var merged *entities.PullRequest
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		pr, err := s.lockPR(txCtx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == entities.PRStatusMerged {
+			merged = pr
+			return nil
+		}
+		if pr.Status == entities.PRStatusClosed {
+			return errors.ErrPRClosed
+		}
+		author, err := s.users.GetByID(txCtx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		var team *entities.Team
+		if author != nil {
+			if team, err = s.teams.GetByName(txCtx, author.TeamName); err != nil {
+				return err
+			}
+		}
+		if err := checkMergePolicy(team, pr); err != nil {
+			return err
+		}
+		if err := s.prs.MarkMerged(txCtx, prID); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, entities.PREvent{PRID: prID, Type: entities.PREventMerged}); err != nil {
+			return err
+		}
+		if err := s.assignQueued(txCtx); err != nil {
+			return err
+		}
+		merged, err = s.prs.GetByID(txCtx, prID)
+		return err
+	})
+	return merged, err
+}
+
+func (s *prService) Close(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	var closed *entities.PullRequest
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		pr, err := s.lockPR(txCtx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == entities.PRStatusMerged {
+			return errors.ErrPRAlreadyMerged
+		}
+		if pr.Status == entities.PRStatusClosed {
+			closed = pr
+			return nil
+		}
+		if err := s.prs.MarkClosed(txCtx, prID); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, entities.PREvent{PRID: prID, Type: entities.PREventClosed}); err != nil {
+			return err
+		}
+		if err := s.assignQueued(txCtx); err != nil {
+			return err
+		}
+		closed, err = s.prs.GetByID(txCtx, prID)
+		return err
+	})
+	return closed, err
+}
+
+func (s *prService) History(ctx context.Context, prID string) ([]entities.PREvent, error) {
+	if _, err := s.GetPR(ctx, prID); err != nil {
+		return nil, err
+	}
+	return s.events.ListByPR(ctx, prID)
+}
+
+// EventsSince reads the settled events of the organization's PR log after
+// f.AfterID.
+func (s *prService) EventsSince(ctx context.Context, f entities.PREventFilter) (*entities.PREventPage, error) {
+	return s.events.ListSince(ctx, f)
+}
+
+// LastEventID returns the id of the newest settled event of the
+// organization's log.
+func (s *prService) LastEventID(ctx context.Context) (int64, error) {
+	return s.events.SettledID(ctx)
+}
+
+// ExplainAssignment returns the decision records of the PR's reviewer picks,
+// only those that picked reviewerID when it is set.
+func (s *prService) ExplainAssignment(ctx context.Context, prID, reviewerID string) ([]entities.AssignmentDecision, error) {
+	if _, err := s.GetPR(ctx, prID); err != nil {
+		return nil, err
+	}
+	decisions, err := s.decisions.ListByPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewerID == "" {
+		return decisions, nil
+	}
+	var result []entities.AssignmentDecision
+	for _, d := range decisions {
+		if d.ReviewerID == reviewerID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (s *prService) GetPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
 	pr, err := s.prs.GetByID(ctx, prID)
-	if err != nil || pr == nil {
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
 		return nil, errors.ErrPRNotFound
 	}
-	if pr.Status == entities.PRStatusMerged {
-		return pr, nil
+	return pr, nil
+}
+
+func (s *prService) Search(ctx context.Context, filter entities.PRSearchFilter, cursor string) (*entities.PRPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.prs.MarkMerged(ctx, prID); err != nil {
+	limit := clampPageSize(filter.Limit)
+	filter.After = after
+	filter.Limit = limit + 1
+
+	prs, err := s.prs.Search(ctx, filter)
+	if err != nil {
 		return nil, err
 	}
-	return s.prs.GetByID(ctx, prID)
+	page := &entities.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodeCursor(prs[limit-1])
+	}
+	return page, nil
 }
 
-func (s *prService) ListByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequest, error) {
-	return s.prs.ListByReviewer(ctx, reviewerID)
-}
+func (s *prService) ListByReviewer(ctx context.Context, filter entities.ReviewListFilter, cursor string) (*entities.ReviewPage, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit := clampPageSize(filter.Limit)
+	filter.After = after
+	filter.Limit = limit + 1
 
-func pickTwoRandom(users []entities.User) []entities.User {
-	n := len(users)
-	if n == 0 {
-		return nil
+	prs, err := s.prs.ListByReviewer(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
-	if n == 1 {
-		return []entities.User{users[0]}
+	counts, err := s.prs.CountByReviewer(ctx, filter.ReviewerID)
+	if err != nil {
+		return nil, err
 	}
-	i := rnd.Intn(n)
-	j := rnd.Intn(n - 1)
-	if j >= i {
-		j++
+	page := &entities.ReviewPage{PRPage: entities.PRPage{PullRequests: prs}, Counts: counts}
+	if !filter.All && len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodeCursor(prs[limit-1])
 	}
-	return []entities.User{users[i], users[j]}
+	return page, nil
 }
 
-func IsUniqueViolation(err error) bool {
-	if err == nil {
-		return false
+// checkMergePolicy verifies that a PR whose size requires a lead in the
+// author's team has been approved by a lead: the reviewer in the lead slot or
+// a lead of the team. This holds even while the lead slot is still queued.
+// Approvals from shadow reviewers never satisfy a policy.
+func checkMergePolicy(team *entities.Team, pr *entities.PullRequest) error {
+	if team == nil || !team.Settings.RequiresLead(pr.PRSize) {
+		return nil
 	}
-	if pgErr, ok := err.(*pgconn.PgError); ok {
-		return pgErr.Code == "23505"
+	for _, id := range pr.Approvals {
+		if containsID(pr.ShadowReviewers, id) {
+			continue
+		}
+		if containsID(pr.LeadReviewers, id) {
+			return nil
+		}
+		for _, m := range team.Members {
+			if m.UserID == id && isLead(m) {
+				return nil
+			}
+		}
+	}
+	return errors.ErrLeadApprovalRequired
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
 	}
 	return false
 }
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err, possibly wrapped, is a unique
+// constraint violation reported by Postgres.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return stderrors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,563 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+// TestReviewerSelection creates PRs against in-memory repositories with the
+// deterministic random source, so every pick is fixed by the PR id and the
+// test can name the exact reviewers and the decision records explaining them.
+func TestReviewerSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		prID     string
+		size     entities.PRSize
+		settings entities.TeamSettings
+		members  []entities.TeamMember
+		// parent is the only member of the parent team "platform".
+		parent    *entities.TeamMember
+		pairings  map[string]int
+		reviewers []string
+		queued    bool
+		decisions []string
+	}{
+		{
+			name:      "random",
+			prID:      "pr-1",
+			settings:  entities.TeamSettings{ReviewersCount: 2},
+			members:   []entities.TeamMember{member("bob"), member("carol"), member("dave"), member("erin")},
+			reviewers: []string{"bob", "dave"},
+			decisions: []string{
+				"regular bob from [bob carol dave erin], excluded [alice:author]",
+				"regular dave from [carol dave erin], excluded [alice:author bob:already_assigned]",
+			},
+		},
+		{
+			name:      "zero weight is never picked",
+			prID:      "pr-2",
+			settings:  entities.TeamSettings{ReviewersCount: 2},
+			members:   []entities.TeamMember{weighted(member("bob"), 0), member("carol"), member("dave")},
+			reviewers: []string{"dave", "carol"},
+			decisions: []string{
+				"regular dave from [bob carol dave], excluded [alice:author]",
+				"regular carol from [bob carol], excluded [alice:author dave:already_assigned]",
+			},
+		},
+		{
+			name:      "lead for a large PR",
+			prID:      "pr-3",
+			size:      entities.PRSize{LinesChanged: 500},
+			settings:  entities.TeamSettings{ReviewersCount: 2, LeadReviewMinLines: 100},
+			members:   []entities.TeamMember{member("bob"), lead(member("carol")), member("dave")},
+			reviewers: []string{"carol", "dave"},
+			decisions: []string{
+				"lead carol from [carol], excluded [alice:author bob:ineligible dave:ineligible]",
+				"regular dave from [bob dave], excluded [alice:author carol:already_assigned]",
+			},
+		},
+		{
+			name:     "junior gets a senior",
+			prID:     "pr-4",
+			settings: entities.TeamSettings{ReviewersCount: 2, MentorshipRequired: true},
+			members: []entities.TeamMember{
+				senior(member("bob")), junior(member("carol")), middle(member("dave")),
+			},
+			reviewers: []string{"carol", "bob"},
+			decisions: []string{
+				"regular carol from [bob carol dave], excluded [alice:author]",
+				"regular bob from [bob], excluded [alice:author carol:already_assigned dave:ineligible]",
+			},
+		},
+		{
+			name: "junior without a senior is left out",
+			// pr-9 draws bob first, who then cannot get a senior.
+			prID:      "pr-9",
+			settings:  entities.TeamSettings{ReviewersCount: 2, MentorshipRequired: true},
+			members:   []entities.TeamMember{junior(member("bob")), middle(member("carol"))},
+			reviewers: []string{"carol"},
+			decisions: []string{
+				"regular carol from [carol], excluded [alice:author bob:no_mentor]",
+			},
+		},
+		{
+			name:      "queued at capacity",
+			prID:      "pr-6",
+			settings:  entities.TeamSettings{ReviewersCount: 2, MaxOpenReviews: 1, OverloadPolicy: entities.OverloadQueue},
+			members:   []entities.TeamMember{busy(member("bob")), member("carol")},
+			reviewers: []string{"carol"},
+			queued:    true,
+			decisions: []string{
+				"regular carol from [carol], excluded [alice:author bob:at_capacity]",
+			},
+		},
+		{
+			name:      "escalated to the parent team",
+			prID:      "pr-7",
+			settings:  entities.TeamSettings{ReviewersCount: 2, EscalateToParent: true},
+			members:   []entities.TeamMember{member("bob")},
+			parent:    ptr(member("zoe")),
+			reviewers: []string{"bob", "zoe"},
+			decisions: []string{
+				"regular bob from [bob], excluded [alice:author]",
+				"regular zoe from [zoe], excluded [alice:author bob:already_assigned]",
+			},
+		},
+		{
+			name: "spread knowledge",
+			prID: "pr-8",
+			settings: entities.TeamSettings{
+				ReviewersCount: 1, Strategy: entities.StrategySpreadKnowledge, PairingWindowDays: 30, PairingDecay: 0.5,
+			},
+			members:   []entities.TeamMember{member("bob"), member("carol")},
+			pairings:  map[string]int{"bob": 3},
+			reviewers: []string{"carol"},
+			decisions: []string{
+				"regular carol from [bob carol], excluded [alice:author]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore()
+			team := &entities.Team{TeamName: "backend", Settings: tt.settings}
+			if tt.parent != nil {
+				team.ParentTeam = "platform"
+				store.addTeam(&entities.Team{TeamName: "platform"}, []entities.TeamMember{*tt.parent})
+			}
+			store.addTeam(team, append([]entities.TeamMember{member("alice")}, tt.members...))
+			store.pairings = tt.pairings
+			svc := store.service()
+			ctx := context.Background()
+
+			preview, err := svc.PreviewAssignment(ctx, tt.prID, "alice", tt.size)
+			if err != nil {
+				t.Fatalf("preview: %v", err)
+			}
+			pr, err := svc.CreatePR(ctx, tt.prID, "change", "alice", tt.size)
+			if err != nil {
+				t.Fatalf("create PR: %v", err)
+			}
+			if !reflect.DeepEqual(pr.Reviewers, tt.reviewers) {
+				t.Errorf("reviewers = %v, want %v", pr.Reviewers, tt.reviewers)
+			}
+			if pr.Queued != tt.queued {
+				t.Errorf("queued = %v, want %v", pr.Queued, tt.queued)
+			}
+
+			decisions, err := svc.ExplainAssignment(ctx, tt.prID, "")
+			if err != nil {
+				t.Fatalf("explain: %v", err)
+			}
+			if got := describe(decisions); !reflect.DeepEqual(got, tt.decisions) {
+				t.Errorf("decisions =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.decisions, "\n"))
+			}
+			if got := describe(preview.Decisions); !reflect.DeepEqual(got, tt.decisions) {
+				t.Errorf("preview decisions =\n%s\nwant the decisions of the created PR", strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+// TestSpreadKnowledgeScores checks the factors the decision record shows for
+// reviewers the author has paired with before.
+func TestSpreadKnowledgeScores(t *testing.T) {
+	store := newStore()
+	store.addTeam(&entities.Team{TeamName: "backend", Settings: entities.TeamSettings{
+		ReviewersCount: 1, Strategy: entities.StrategySpreadKnowledge, PairingWindowDays: 30, PairingDecay: 0.5,
+	}}, []entities.TeamMember{member("alice"), member("bob"), weighted(member("carol"), 2)})
+	store.pairings = map[string]int{"bob": 2}
+
+	preview, err := store.service().PreviewAssignment(context.Background(), "pr-1", "alice", entities.PRSize{})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	want := []entities.CandidateScore{
+		{UserID: "carol", TeamName: "backend", Weight: 2, Factor: 1, Score: 2, Probability: 2 / 2.25},
+		{UserID: "bob", TeamName: "backend", Weight: 1, Factor: 0.25, Score: 0.25, Probability: 0.25 / 2.25},
+	}
+	if !reflect.DeepEqual(preview.Ranking, want) {
+		t.Errorf("ranking = %+v, want %+v", preview.Ranking, want)
+	}
+	if preview.Strategy != entities.StrategySpreadKnowledge {
+		t.Errorf("strategy = %q", preview.Strategy)
+	}
+}
+
+// TestReviewListPageSize lists 25 reviews: 20 on the default page, the rest
+// after its cursor, and all of them at once when the filter asks for all.
+func TestReviewListPageSize(t *testing.T) {
+	st := newStore()
+	for i := 0; i < 25; i++ {
+		id := fmt.Sprintf("pr-%02d", i)
+		st.prs[id] = &entities.PullRequest{PRID: id, CreatedAt: time.Unix(int64(i), 0)}
+	}
+	svc := st.service()
+	ctx := context.Background()
+
+	first, err := svc.ListByReviewer(ctx, entities.ReviewListFilter{ReviewerID: "u1"}, "")
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if len(first.PullRequests) != 20 || first.NextCursor == "" {
+		t.Fatalf("first page has %d PRs and cursor %q, want 20 and a cursor", len(first.PullRequests), first.NextCursor)
+	}
+	second, err := svc.ListByReviewer(ctx, entities.ReviewListFilter{ReviewerID: "u1"}, first.NextCursor)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if len(second.PullRequests) != 5 || second.NextCursor != "" || second.PullRequests[0].PRID != "pr-04" {
+		t.Fatalf("second page = %+v with cursor %q, want 5 PRs from pr-04 and no cursor",
+			second.PullRequests, second.NextCursor)
+	}
+
+	all, err := svc.ListByReviewer(ctx, entities.ReviewListFilter{ReviewerID: "u1", All: true}, "")
+	if err != nil {
+		t.Fatalf("all reviews: %v", err)
+	}
+	if len(all.PullRequests) != 25 || all.NextCursor != "" || all.Counts.Total != 25 {
+		t.Errorf("all reviews = %d PRs, cursor %q, counts %+v; want 25 without a cursor",
+			len(all.PullRequests), all.NextCursor, all.Counts)
+	}
+}
+
+// TestLookupErrors reads a PR's history and assignment decisions from a
+// missing PR and from a failing repository. Only the missing PR may be
+// reported as not found.
+func TestLookupErrors(t *testing.T) {
+	broken := stderrors.New("connection reset")
+	calls := map[string]func(interfaces.PRService) error{
+		"history": func(svc interfaces.PRService) error {
+			_, err := svc.History(context.Background(), "pr-1")
+			return err
+		},
+		"explain": func(svc interfaces.PRService) error {
+			_, err := svc.ExplainAssignment(context.Background(), "pr-1", "")
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			st := newStore()
+			if err := call(st.service()); !stderrors.Is(err, errors.ErrPRNotFound) {
+				t.Errorf("missing PR: err = %v, want ErrPRNotFound", err)
+			}
+			st.err = broken
+			if err := call(st.service()); !stderrors.Is(err, broken) {
+				t.Errorf("failing repository: err = %v, want %v", err, broken)
+			}
+		})
+	}
+}
+
+// TestSearchCursor pages through PRs that share creation times. The cursor of
+// each page must decode to the creation time, to the nanosecond, and id of the
+// page's last PR, and malformed cursors must be rejected before any search.
+func TestSearchCursor(t *testing.T) {
+	st := newStore()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+	for i, created := range []time.Time{base, base, base, base.Add(-time.Second), base.Add(-time.Second)} {
+		id := fmt.Sprintf("pr-%d", i+1)
+		st.prs[id] = &entities.PullRequest{PRID: id, CreatedAt: created}
+	}
+	svc := st.service()
+
+	var got []string
+	cursor := ""
+	for {
+		page, err := svc.Search(context.Background(), entities.PRSearchFilter{Limit: 2}, cursor)
+		if err != nil {
+			t.Fatalf("search after %q: %v", cursor, err)
+		}
+		if cursor != "" {
+			last := st.prs[got[len(got)-1]]
+			if after := st.searched.After; after == nil || !after.CreatedAt.Equal(last.CreatedAt) || after.PRID != last.PRID {
+				t.Fatalf("cursor %q decodes to %+v, want the time and id of %s", cursor, after, last.PRID)
+			}
+		}
+		for _, pr := range page.PullRequests {
+			got = append(got, pr.PRID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if want := []string{"pr-3", "pr-2", "pr-1", "pr-5", "pr-4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("2025-03-01T12:00:00Z")),
+		base64.RawURLEncoding.EncodeToString([]byte("2025-03-01T12:00:00Z|")),
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday|pr-1")),
+	} {
+		st.searched = nil
+		if _, err := svc.Search(context.Background(), entities.PRSearchFilter{}, bad); !stderrors.Is(err, errors.ErrInvalidCursor) {
+			t.Errorf("cursor %q: err = %v, want ErrInvalidCursor", bad, err)
+		}
+		if st.searched != nil {
+			t.Errorf("cursor %q reached the repository", bad)
+		}
+	}
+}
+
+// describe renders a decision record as one line: the slot, the pick, the
+// candidate pool and the excluded members with their reasons.
+func describe(decisions []entities.AssignmentDecision) []string {
+	out := make([]string, 0, len(decisions))
+	for _, d := range decisions {
+		var candidates, excluded []string
+		for _, c := range d.Candidates {
+			candidates = append(candidates, c.UserID)
+		}
+		for _, e := range d.Excluded {
+			excluded = append(excluded, e.UserID+":"+string(e.Reason))
+		}
+		out = append(out, fmt.Sprintf("%s %s from %v, excluded %v", d.Slot, d.ReviewerID, candidates, excluded))
+	}
+	return out
+}
+
+func member(id string) entities.TeamMember {
+	return entities.TeamMember{
+		User:         entities.User{UserID: id, Username: id, IsActive: true},
+		ReviewWeight: 1,
+		Role:         entities.MemberRoleMember,
+	}
+}
+
+func weighted(m entities.TeamMember, w float64) entities.TeamMember {
+	m.ReviewWeight = w
+	return m
+}
+
+func lead(m entities.TeamMember) entities.TeamMember {
+	m.Role = entities.MemberRoleLead
+	return m
+}
+
+func junior(m entities.TeamMember) entities.TeamMember {
+	m.Seniority = entities.SeniorityJunior
+	return m
+}
+
+func middle(m entities.TeamMember) entities.TeamMember {
+	m.Seniority = entities.SeniorityMiddle
+	return m
+}
+
+func senior(m entities.TeamMember) entities.TeamMember {
+	m.Seniority = entities.SenioritySenior
+	return m
+}
+
+// busy gives m one pending review.
+func busy(m entities.TeamMember) entities.TeamMember {
+	m.OpenReviews = 1
+	return m
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// store keeps the data of the in-memory repositories. Methods the PR service
+// does not call for reviewer selection are left to the embedded interfaces.
+type store struct {
+	teams     map[string]*entities.Team
+	members   map[string][]entities.TeamMember
+	prs       map[string]*entities.PullRequest
+	decisions []entities.AssignmentDecision
+	pairings  map[string]int
+	// err fails every PR lookup when set.
+	err error
+	// searched is the filter of the last search.
+	searched *entities.PRSearchFilter
+}
+
+func newStore() *store {
+	return &store{
+		teams:   map[string]*entities.Team{},
+		members: map[string][]entities.TeamMember{},
+		prs:     map[string]*entities.PullRequest{},
+	}
+}
+
+func (s *store) addTeam(team *entities.Team, members []entities.TeamMember) {
+	for i := range members {
+		members[i].TeamName = team.TeamName
+		members[i].IsPrimary = true
+	}
+	team.Members = members
+	s.teams[team.TeamName] = team
+	s.members[team.TeamName] = members
+}
+
+func (s *store) service() interfaces.PRService {
+	inTx := func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }
+	return services.NewPRService(
+		userRepo{s: s}, teamRepo{s: s}, prRepo{s: s}, eventRepo{}, decisionRepo{s: s},
+		services.NewDeterministicSource(), notifier{}, inTx, inTx,
+	)
+}
+
+type userRepo struct {
+	repositories.UserRepository
+	s *store
+}
+
+func (r userRepo) GetByID(_ context.Context, id string) (*entities.User, error) {
+	for _, members := range r.s.members {
+		for _, m := range members {
+			if m.UserID == id {
+				u := m.User
+				return &u, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r userRepo) ListCandidates(_ context.Context, team string) ([]entities.TeamMember, error) {
+	return append([]entities.TeamMember(nil), r.s.members[team]...), nil
+}
+
+type teamRepo struct {
+	repositories.TeamRepository
+	s *store
+}
+
+func (r teamRepo) GetByName(_ context.Context, name string) (*entities.Team, error) {
+	return r.s.teams[name], nil
+}
+
+type prRepo struct {
+	repositories.PullRequestRepository
+	s *store
+}
+
+func (r prRepo) Create(_ context.Context, pr *entities.PullRequest) error {
+	stored := *pr
+	r.s.prs[pr.PRID] = &stored
+	return nil
+}
+
+func (r prRepo) GetByID(_ context.Context, id string) (*entities.PullRequest, error) {
+	if r.s.err != nil {
+		return nil, r.s.err
+	}
+	pr, ok := r.s.prs[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *pr
+	return &cp, nil
+}
+
+// Search returns the stored PRs in the order of the keyset, newest first and
+// by descending id among equal times.
+func (r prRepo) Search(_ context.Context, f entities.PRSearchFilter) ([]entities.PullRequest, error) {
+	r.s.searched = &f
+	var out []entities.PullRequest
+	for _, pr := range r.s.prs {
+		if f.After != nil && !pr.CreatedAt.Before(f.After.CreatedAt) &&
+			(!pr.CreatedAt.Equal(f.After.CreatedAt) || pr.PRID >= f.After.PRID) {
+			continue
+		}
+		out = append(out, *pr)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].PRID > out[j].PRID
+	})
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+// ListByReviewer lists every stored PR in the keyset order; All drops the limit.
+func (r prRepo) ListByReviewer(ctx context.Context, f entities.ReviewListFilter) ([]entities.PullRequest, error) {
+	limit := f.Limit
+	if f.All {
+		limit = len(r.s.prs)
+	}
+	return r.Search(ctx, entities.PRSearchFilter{After: f.After, Limit: limit})
+}
+
+func (r prRepo) CountByReviewer(context.Context, string) (entities.ReviewCounts, error) {
+	return entities.ReviewCounts{Total: len(r.s.prs)}, nil
+}
+
+func (r prRepo) AssignReviewers(_ context.Context, prID string, reviewers []entities.ReviewerAssignment) error {
+	pr := r.s.prs[prID]
+	for _, a := range reviewers {
+		pr.Reviewers = append(pr.Reviewers, a.ReviewerID)
+		switch a.Slot {
+		case entities.ReviewerSlotLead:
+			pr.LeadReviewers = append(pr.LeadReviewers, a.ReviewerID)
+		case entities.ReviewerSlotShadow:
+			pr.ShadowReviewers = append(pr.ShadowReviewers, a.ReviewerID)
+		}
+	}
+	return nil
+}
+
+func (r prRepo) MarkOverloaded(_ context.Context, prID string) error {
+	r.s.prs[prID].Overloaded = true
+	return nil
+}
+
+func (r prRepo) SetQueued(_ context.Context, prID string, queued bool) error {
+	r.s.prs[prID].Queued = queued
+	return nil
+}
+
+func (r prRepo) CountPairings(context.Context, string, time.Time) (map[string]int, error) {
+	return r.s.pairings, nil
+}
+
+type eventRepo struct {
+	repositories.PREventRepository
+}
+
+func (eventRepo) Append(context.Context, *entities.PREvent) error { return nil }
+
+type decisionRepo struct {
+	repositories.AssignmentDecisionRepository
+	s *store
+}
+
+func (r decisionRepo) Append(_ context.Context, d *entities.AssignmentDecision) error {
+	r.s.decisions = append(r.s.decisions, *d)
+	return nil
+}
+
+func (r decisionRepo) ListByPR(_ context.Context, prID string) ([]entities.AssignmentDecision, error) {
+	var out []entities.AssignmentDecision
+	for _, d := range r.s.decisions {
+		if d.PRID == prID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+type notifier struct{}
+
+func (notifier) Notify(string) {}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// Rand is the generator a single reviewer selection draws from.
+type Rand interface {
+	Float64() float64
+}
+
+// RandomSource hands out the generator for one selection. key identifies the
+// selection: the PR id for the initial assignment, extended with the replaced
+// reviewer for replacements. Implementations must be safe for concurrent use.
+type RandomSource interface {
+	Rand(key string) Rand
+}
+
+// NewRandomSource returns a source shared by all selections, seeded once.
+func NewRandomSource(seed int64) RandomSource {
+	return &sharedSource{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// NewDeterministicSource returns a source that seeds every selection from its
+// key, so the same PR with the same candidates always gets the same reviewers.
+func NewDeterministicSource() RandomSource {
+	return deterministicSource{}
+}
+
+// sharedSource serialises access to one generator, since *rand.Rand is not
+// safe for concurrent use.
+type sharedSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func (s *sharedSource) Rand(string) Rand {
+	return s
+}
+
+func (s *sharedSource) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64()
+}
+
+type deterministicSource struct{}
+
+func (deterministicSource) Rand(key string) Rand {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// selectionKey identifies a selection for the random source.
+func selectionKey(prID, replacedReviewerID string) string {
+	if replacedReviewerID == "" {
+		return prID
+	}
+	return prID + "/" + replacedReviewerID
+}
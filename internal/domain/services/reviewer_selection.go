@@ -0,0 +1,569 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+// selection is the state of choosing reviewers for one PR. Every pick is
+// explained by a decision record.
+type selection struct {
+	prID     string
+	strategy entities.SelectionStrategy
+	// replaced is the reviewer being replaced, if any.
+	replaced string
+	// excluded users are never picked; picked users are added to it.
+	excluded map[string]entities.ExclusionReason
+	// factors multiply the review weight of the listed users.
+	factors map[string]float64
+	// ignoreCapacity lets members at their open review limit be picked.
+	ignoreCapacity bool
+	// capped records that a candidate was skipped for being at capacity.
+	capped    bool
+	rnd       Rand
+	decisions []entities.AssignmentDecision
+}
+
+// newSelection starts a selection for pr that skips its author and current
+// reviewers and draws from the generator the random source gives for key.
+func (s *prService) newSelection(ctx context.Context, team *entities.Team, pr *entities.PullRequest, key string) (*selection, error) {
+	factors, err := s.pairingFactors(ctx, team, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	sel := &selection{
+		prID:     pr.PRID,
+		strategy: entities.StrategyRandom,
+		excluded: map[string]entities.ExclusionReason{pr.AuthorID: entities.ExclusionAuthor},
+		factors:  factors,
+		rnd:      s.random.Rand(key),
+	}
+	if team != nil && team.Settings.Strategy != "" {
+		sel.strategy = team.Settings.Strategy
+	}
+	for _, id := range pr.Reviewers {
+		sel.excluded[id] = entities.ExclusionAssigned
+	}
+	return sel, nil
+}
+
+// selectReviewers picks up to n reviewers for slot among the active members
+// of team, skipping excluded users, members at capacity and, when accept is
+// set, members it rejects. When the team cannot fill every slot and allows
+// escalation, the remaining slots are filled from its parent, then from the
+// grandparent and so on. Each pick adds a decision record to sel.
+func (s *prService) selectReviewers(
+	ctx context.Context,
+	team *entities.Team,
+	sel *selection,
+	slot entities.ReviewerSlot,
+	n int,
+	accept func(entities.TeamMember) bool,
+) ([]entities.TeamMember, error) {
+	var picked []entities.TeamMember
+	candidates := []entities.CandidateScore{}
+	excluded := []entities.ExcludedCandidate{}
+	seen := map[string]bool{}
+	visited := map[string]bool{}
+	for team != nil && len(picked) < n && !visited[team.TeamName] {
+		visited[team.TeamName] = true
+
+		members, err := s.users.ListCandidates(ctx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		var pool []entities.TeamMember
+		var weights, factors []float64
+		for _, m := range members {
+			if seen[m.UserID] {
+				continue
+			}
+			seen[m.UserID] = true
+			reason, skip := sel.excluded[m.UserID]
+			switch {
+			case skip:
+			case !m.IsActive:
+				reason, skip = entities.ExclusionInactive, true
+			case accept != nil && !accept(m):
+				reason, skip = entities.ExclusionIneligible, true
+			case !sel.ignoreCapacity && atCapacity(m, team.Settings):
+				reason, skip = entities.ExclusionAtCapacity, true
+				sel.capped = true
+			}
+			if skip {
+				excluded = append(excluded, entities.ExcludedCandidate{UserID: m.UserID, TeamName: team.TeamName, Reason: reason})
+				continue
+			}
+			factor := 1.0
+			if f, ok := sel.factors[m.UserID]; ok {
+				factor = f
+			}
+			weights = append(weights, m.ReviewWeight)
+			factors = append(factors, factor)
+			m.ReviewWeight *= factor
+			pool = append(pool, m)
+		}
+		candidates = append(candidates, scoreCandidates(team.TeamName, pool, weights, factors)...)
+		for _, m := range pickWeighted(sel.rnd, pool, n-len(picked)) {
+			sel.excluded[m.UserID] = entities.ExclusionAssigned
+			picked = append(picked, m)
+		}
+
+		if len(picked) >= n || !team.Settings.EscalateToParent || team.ParentTeam == "" {
+			break
+		}
+		team, err = s.teams.GetByName(ctx, team.ParentTeam)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range picked {
+		sel.decisions = append(sel.decisions, entities.AssignmentDecision{
+			PRID:               sel.prID,
+			ReviewerID:         m.UserID,
+			Slot:               slot,
+			Strategy:           sel.strategy,
+			ReplacedReviewerID: sel.replaced,
+			OverCapacity:       sel.ignoreCapacity,
+			Candidates:         candidates,
+			Excluded:           excluded,
+		})
+	}
+	return picked, nil
+}
+
+// scoreCandidates describes the pool of one team. pool already carries the
+// factored weights; weights and factors hold each member's review weight and
+// the factor applied to it.
+func scoreCandidates(teamName string, pool []entities.TeamMember, weights, factors []float64) []entities.CandidateScore {
+	var total float64
+	for _, m := range pool {
+		total += m.ReviewWeight
+	}
+	scores := make([]entities.CandidateScore, 0, len(pool))
+	for i, m := range pool {
+		c := entities.CandidateScore{
+			UserID:   m.UserID,
+			TeamName: teamName,
+			Weight:   weights[i],
+			Factor:   factors[i],
+			Score:    m.ReviewWeight,
+		}
+		if total > 0 {
+			c.Probability = m.ReviewWeight / total
+		}
+		scores = append(scores, c)
+	}
+	return scores
+}
+
+// atCapacity reports whether m already has as many pending reviews as its own
+// limit or, without one, the team default allows.
+func atCapacity(m entities.TeamMember, settings entities.TeamSettings) bool {
+	limit := settings.MaxOpenReviews
+	if m.MaxOpenReviews != nil {
+		limit = *m.MaxOpenReviews
+	}
+	return limit > 0 && m.OpenReviews >= limit
+}
+
+// pickWeighted draws up to n distinct members, each with probability
+// proportional to its review weight. Members with zero weight are never picked.
+func pickWeighted(rnd Rand, members []entities.TeamMember, n int) []entities.TeamMember {
+	pool := make([]entities.TeamMember, 0, len(members))
+	var total float64
+	for _, m := range members {
+		if m.ReviewWeight > 0 {
+			pool = append(pool, m)
+			total += m.ReviewWeight
+		}
+	}
+	var picked []entities.TeamMember
+	for len(picked) < n && len(pool) > 0 {
+		x := rnd.Float64() * total
+		i := 0
+		for ; i < len(pool)-1; i++ {
+			x -= pool[i].ReviewWeight
+			if x < 0 {
+				break
+			}
+		}
+		picked = append(picked, pool[i])
+		total -= pool[i].ReviewWeight
+		pool = append(pool[:i], pool[i+1:]...)
+	}
+	return picked
+}
+
+func isLead(m entities.TeamMember) bool {
+	return m.Role == entities.MemberRoleLead
+}
+
+// pickReviewers fills the free reviewer slots of pr: a lead when the team
+// requires one for the PR's size, regular reviewers up to the team's count
+// and, when the team asks for it, a junior shadow on top. short reports that
+// a required slot stayed empty because candidates were at capacity.
+func (s *prService) pickReviewers(
+	ctx context.Context,
+	team *entities.Team,
+	pr *entities.PullRequest,
+	sel *selection,
+) (assignments []entities.ReviewerAssignment, short bool, err error) {
+	settings := entities.TeamSettings{ReviewersCount: entities.DefaultReviewersCount}
+	if team != nil {
+		settings = team.Settings
+	}
+	var picked []entities.TeamMember
+	for _, id := range pr.Reviewers {
+		if containsID(pr.ShadowReviewers, id) {
+			continue
+		}
+		u, err := s.users.GetByID(ctx, id)
+		if err != nil {
+			return nil, false, err
+		}
+		if u != nil {
+			picked = append(picked, entities.TeamMember{User: *u})
+		}
+	}
+
+	if team != nil && settings.RequiresLead(pr.PRSize) && len(pr.LeadReviewers) == 0 {
+		leads, err := s.selectReviewers(ctx, team, sel, entities.ReviewerSlotLead, 1, isLead)
+		if err != nil {
+			return nil, false, err
+		}
+		switch {
+		case len(leads) > 0:
+			picked = append(picked, leads[0])
+			assignments = append(assignments, entities.ReviewerAssignment{ReviewerID: leads[0].UserID, Slot: entities.ReviewerSlotLead})
+		case sel.capped:
+			short = true
+		default:
+			return nil, false, errors.ErrNoLeadCandidate
+		}
+	}
+
+	// Regular slots are drawn one at a time so the mentorship rule can look
+	// at who is already reviewing.
+	for len(picked) < settings.ReviewersCount {
+		accept := mentorshipFilter(settings, picked, settings.ReviewersCount-len(picked))
+		capped := sel.capped
+		sel.capped = false
+		got, err := s.selectReviewers(ctx, team, sel, entities.ReviewerSlotRegular, 1, accept)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(got) == 0 && accept != nil && !sel.capped {
+			// No one can mentor a junior picked in this round, not even over
+			// capacity: leave the junior out instead. Mentors at capacity are
+			// left to the overload policy like any other short slot.
+			var dropped bool
+			picked, assignments, dropped = dropJunior(sel, picked, assignments)
+			if dropped {
+				sel.capped = capped
+				continue
+			}
+		}
+		sel.capped = sel.capped || capped
+		if len(got) == 0 {
+			short = short || sel.capped
+			break
+		}
+		picked = append(picked, got[0])
+		assignments = append(assignments, entities.ReviewerAssignment{ReviewerID: got[0].UserID, Slot: entities.ReviewerSlotRegular})
+	}
+
+	if team != nil && settings.ShadowJuniors && len(pr.ShadowReviewers) == 0 {
+		shadows, err := s.selectReviewers(ctx, team, sel, entities.ReviewerSlotShadow, 1, isJunior)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(shadows) > 0 {
+			assignments = append(assignments, entities.ReviewerAssignment{ReviewerID: shadows[0].UserID, Slot: entities.ReviewerSlotShadow})
+		}
+	}
+	return assignments, short, nil
+}
+
+// assignmentPlan is the outcome of choosing reviewers for a PR before
+// anything is written.
+type assignmentPlan struct {
+	assignments []entities.ReviewerAssignment
+	// overloaded is set when some reviewer was assigned beyond capacity.
+	overloaded bool
+	// queued is set when slots stay empty until capacity frees up.
+	queued    bool
+	decisions []entities.AssignmentDecision
+}
+
+// planReviewers chooses reviewers for the free slots of pr without writing
+// anything; pr itself is updated in memory. When candidates at capacity leave
+// slots empty, policy decides whether to assign them anyway, queue the PR or
+// fail.
+func (s *prService) planReviewers(ctx context.Context, team *entities.Team, pr *entities.PullRequest, policy entities.OverloadPolicy) (*assignmentPlan, error) {
+	sel, err := s.newSelection(ctx, team, pr, selectionKey(pr.PRID, ""))
+	if err != nil {
+		return nil, err
+	}
+	assignments, short, err := s.pickReviewers(ctx, team, pr, sel)
+	if err != nil {
+		return nil, err
+	}
+	addAssignments(pr, assignments)
+	plan := &assignmentPlan{assignments: assignments}
+
+	if short {
+		switch policy {
+		case entities.OverloadFail:
+			return nil, errors.ErrReviewersAtCapacity
+		case entities.OverloadQueue:
+			plan.queued = true
+		default:
+			sel.ignoreCapacity, sel.capped = true, false
+			more, _, err := s.pickReviewers(ctx, team, pr, sel)
+			if err != nil {
+				return nil, err
+			}
+			addAssignments(pr, more)
+			plan.assignments = append(plan.assignments, more...)
+			plan.overloaded = len(more) > 0
+		}
+	}
+	plan.decisions = sel.decisions
+	return plan, nil
+}
+
+// fillReviewers assigns reviewers to the free slots of pr as planned by
+// planReviewers and records the assignments.
+func (s *prService) fillReviewers(ctx context.Context, team *entities.Team, pr *entities.PullRequest, policy entities.OverloadPolicy) error {
+	plan, err := s.planReviewers(ctx, team, pr, policy)
+	if err != nil {
+		return err
+	}
+	if plan.overloaded && !pr.Overloaded {
+		if err := s.prs.MarkOverloaded(ctx, pr.PRID); err != nil {
+			return err
+		}
+		pr.Overloaded = true
+	}
+	if plan.queued != pr.Queued {
+		if err := s.prs.SetQueued(ctx, pr.PRID, plan.queued); err != nil {
+			return err
+		}
+		pr.Queued = plan.queued
+	}
+
+	if len(plan.assignments) == 0 {
+		return nil
+	}
+	if err := s.prs.AssignReviewers(ctx, pr.PRID, plan.assignments); err != nil {
+		return err
+	}
+	if err := s.saveDecisions(ctx, plan.decisions); err != nil {
+		return err
+	}
+	for _, a := range plan.assignments {
+		if err := s.record(ctx, entities.PREvent{PRID: pr.PRID, Type: entities.PREventReviewerAssigned, NewReviewerID: a.ReviewerID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignQueued retries the assignment of every queued PR, oldest first. It
+// runs inside the transaction that completed a review or closed a PR, so the
+// freed capacity is handed out before anyone else can take it. Queued PRs
+// that another transaction is changing are left to the next completion.
+func (s *prService) assignQueued(ctx context.Context) error {
+	queued, err := s.prs.LockQueued(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range queued {
+		pr, err := s.prs.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			continue
+		}
+		author, err := s.users.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			continue
+		}
+		team, err := s.teams.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+		err = s.fillReviewers(ctx, team, pr, entities.OverloadQueue)
+		if err == errors.ErrNoLeadCandidate {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *prService) saveDecisions(ctx context.Context, decisions []entities.AssignmentDecision) error {
+	for i := range decisions {
+		if err := s.decisions.Append(ctx, &decisions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rankCandidates merges the candidates of all decisions, keeping the first
+// score seen for each user, ordered by score.
+func rankCandidates(decisions []entities.AssignmentDecision) []entities.CandidateScore {
+	seen := map[string]bool{}
+	var ranking []entities.CandidateScore
+	for _, d := range decisions {
+		for _, c := range d.Candidates {
+			if !seen[c.UserID] {
+				seen[c.UserID] = true
+				ranking = append(ranking, c)
+			}
+		}
+	}
+	sort.SliceStable(ranking, func(i, j int) bool { return ranking[i].Score > ranking[j].Score })
+	return ranking
+}
+
+// addAssignments adds newly assigned reviewers to pr.
+func addAssignments(pr *entities.PullRequest, assignments []entities.ReviewerAssignment) {
+	for _, a := range assignments {
+		pr.Reviewers = append(pr.Reviewers, a.ReviewerID)
+		switch a.Slot {
+		case entities.ReviewerSlotLead:
+			pr.LeadReviewers = append(pr.LeadReviewers, a.ReviewerID)
+		case entities.ReviewerSlotShadow:
+			pr.ShadowReviewers = append(pr.ShadowReviewers, a.ReviewerID)
+		}
+	}
+}
+
+// overloadPolicy returns the team's overload policy, assigning anyway when
+// there is no team.
+func overloadPolicy(team *entities.Team) entities.OverloadPolicy {
+	if team == nil || team.Settings.OverloadPolicy == "" {
+		return entities.OverloadAssign
+	}
+	return team.Settings.OverloadPolicy
+}
+
+// pairingFactors returns the weight multipliers of the spread knowledge
+// strategy for reviewers of authorID, or nil when the team uses plain random
+// selection.
+func (s *prService) pairingFactors(ctx context.Context, team *entities.Team, authorID string) (map[string]float64, error) {
+	if team == nil || team.Settings.Strategy != entities.StrategySpreadKnowledge {
+		return nil, nil
+	}
+	counts, err := s.prs.CountPairings(ctx, authorID, time.Now().Add(-team.Settings.PairingWindow()))
+	if err != nil {
+		return nil, err
+	}
+	factors := make(map[string]float64, len(counts))
+	for reviewer, n := range counts {
+		factors[reviewer] = math.Pow(team.Settings.PairingDecay, float64(n))
+	}
+	return factors, nil
+}
+
+// mentorshipFilter returns the constraint the next regular reviewer must meet
+// so that no junior reviews without a senior next to them, or nil when anyone
+// fits. Only the last free slot is constrained; earlier slots may still be
+// paired up later, and pickReviewers leaves a junior out when they cannot.
+func mentorshipFilter(settings entities.TeamSettings, others []entities.TeamMember, free int) func(entities.TeamMember) bool {
+	if !settings.MentorshipRequired || free > 1 {
+		return nil
+	}
+	hasJunior := false
+	for _, m := range others {
+		if m.Seniority == entities.SenioritySenior {
+			return nil
+		}
+		if m.Seniority == entities.SeniorityJunior {
+			hasJunior = true
+		}
+	}
+	if hasJunior {
+		return isSenior
+	}
+	return isNotJunior
+}
+
+// dropJunior takes back the last junior picked for a regular slot of this
+// selection together with its decision record. The junior is excluded from
+// the rest of the selection, which the following decision records show.
+func dropJunior(
+	sel *selection,
+	picked []entities.TeamMember,
+	assignments []entities.ReviewerAssignment,
+) ([]entities.TeamMember, []entities.ReviewerAssignment, bool) {
+	for i := len(picked) - 1; i >= 0; i-- {
+		if !isJunior(picked[i]) {
+			continue
+		}
+		id := picked[i].UserID
+		for j, a := range assignments {
+			if a.ReviewerID != id || a.Slot != entities.ReviewerSlotRegular {
+				continue
+			}
+			sel.excluded[id] = entities.ExclusionNoMentor
+			for k := len(sel.decisions) - 1; k >= 0; k-- {
+				if sel.decisions[k].ReviewerID == id {
+					sel.decisions = append(sel.decisions[:k], sel.decisions[k+1:]...)
+					break
+				}
+			}
+			picked = append(picked[:i], picked[i+1:]...)
+			assignments = append(assignments[:j], assignments[j+1:]...)
+			return picked, assignments, true
+		}
+	}
+	return picked, assignments, false
+}
+
+// allOf combines candidate filters; nil filters accept everyone.
+func allOf(filters ...func(entities.TeamMember) bool) func(entities.TeamMember) bool {
+	var active []func(entities.TeamMember) bool
+	for _, f := range filters {
+		if f != nil {
+			active = append(active, f)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	return func(m entities.TeamMember) bool {
+		for _, f := range active {
+			if !f(m) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isJunior(m entities.TeamMember) bool {
+	return m.Seniority == entities.SeniorityJunior
+}
+
+func isNotJunior(m entities.TeamMember) bool {
+	return m.Seniority != entities.SeniorityJunior
+}
+
+func isSenior(m entities.TeamMember) bool {
+	return m.Seniority == entities.SenioritySenior
+}
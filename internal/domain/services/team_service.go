@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
 	"github.com/f4ke-n0name/avito/internal/domain/errors"
@@ -12,32 +13,350 @@ import (
 type teamService struct {
 	teams repositories.TeamRepository
 	users repositories.UserRepository
+
+	withTx func(ctx context.Context, fn func(txCtx context.Context) error) error
 }
 
-func NewTeamService(teams repositories.TeamRepository, users repositories.UserRepository) interfaces.TeamService {
-	return &teamService{teams: teams, users: users}
+func NewTeamService(
+	teams repositories.TeamRepository,
+	users repositories.UserRepository,
+	withTx func(ctx context.Context, fn func(txCtx context.Context) error) error,
+) interfaces.TeamService {
+	return &teamService{teams: teams, users: users, withTx: withTx}
 }
 
-func (s *teamService) CreateTeam(ctx context.Context, team *entities.Team) (*entities.Team, error) {
-	existing, _ := s.teams.GetByName(ctx, team.TeamName)
-	if existing != nil {
-		return nil, errors.ErrTeamExists
+// CreateTeam creates the team and its members in one transaction. Members that
+// already belong to another team are only moved when moveExisting is set;
+// otherwise a *errors.MemberConflictError listing them is returned.
+func (s *teamService) CreateTeam(ctx context.Context, team *entities.Team, moveExisting bool) (*entities.Team, error) {
+	if team.Settings.ReviewersCount == 0 {
+		team.Settings.ReviewersCount = entities.DefaultReviewersCount
 	}
-
-	if err := s.teams.Create(ctx, team); err != nil {
-		return nil, err
+	if team.Settings.Strategy == "" {
+		team.Settings.Strategy = entities.StrategyRandom
 	}
-	for _, member := range team.Members {
-		_ = s.users.CreateOrUpdate(ctx, &member)
+	if team.Settings.PairingWindowDays == 0 {
+		team.Settings.PairingWindowDays = entities.DefaultPairingWindowDays
 	}
+	if team.Settings.PairingDecay == 0 {
+		team.Settings.PairingDecay = entities.DefaultPairingDecay
+	}
+	if team.Settings.OverloadPolicy == "" {
+		team.Settings.OverloadPolicy = entities.OverloadAssign
+	}
+
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		existing, err := s.teams.GetByName(txCtx, team.TeamName)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return errors.ErrTeamExists
+		}
+		if team.ParentTeam != "" {
+			parent, err := s.teams.GetByName(txCtx, team.ParentTeam)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return errors.ErrParentNotFound
+			}
+		}
 
+		var conflicts []errors.MemberConflict
+		for _, member := range team.Members {
+			current, err := s.users.GetByID(txCtx, member.UserID)
+			if err != nil {
+				return err
+			}
+			if current != nil && current.TeamName != "" && current.TeamName != team.TeamName {
+				conflicts = append(conflicts, errors.MemberConflict{UserID: current.UserID, TeamName: current.TeamName})
+			}
+		}
+		if len(conflicts) > 0 && !moveExisting {
+			return &errors.MemberConflictError{Conflicts: conflicts}
+		}
+
+		if err := s.teams.Create(txCtx, team); err != nil {
+			if IsUniqueViolation(err) {
+				return errors.ErrTeamExists
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return team, nil
 }
 
 func (s *teamService) GetTeam(ctx context.Context, teamName string) (*entities.Team, error) {
 	team, err := s.teams.GetByName(ctx, teamName)
-	if err != nil || team == nil {
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
 		return nil, errors.ErrTeamNotFound
 	}
 	return team, nil
 }
+
+// UpdateTeam changes the team's settings, parent and members in one
+// transaction. Added users that do not exist yet are created; existing users
+// keep their user record and only get the membership. Making a member of
+// another team primary here fails with a MemberConflictError unless
+// MoveExistingMembers is set.
+func (s *teamService) UpdateTeam(ctx context.Context, teamName string, upd entities.TeamUpdate) (*entities.Team, error) {
+	var updated *entities.Team
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		team, err := s.teams.GetByName(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return errors.ErrTeamNotFound
+		}
+		if upd.Settings != nil {
+			if err := s.teams.UpdateSettings(txCtx, teamName, *upd.Settings); err != nil {
+				return err
+			}
+		}
+		if upd.ParentTeam != nil {
+			if err := s.setParent(txCtx, teamName, *upd.ParentTeam); err != nil {
+				return err
+			}
+		}
+		for _, id := range upd.RemoveMembers {
+			if err := s.users.RemoveFromTeam(txCtx, id, teamName); err != nil {
+				return err
+			}
+		}
+		existing := make([]*entities.User, len(upd.AddMembers))
+		var conflicts []errors.MemberConflict
+		for i, member := range upd.AddMembers {
+			current, err := s.users.GetByID(txCtx, member.UserID)
+			if err != nil {
+				return err
+			}
+			existing[i] = current
+			if member.IsPrimary && current != nil && current.TeamName != "" && current.TeamName != teamName {
+				conflicts = append(conflicts, errors.MemberConflict{UserID: current.UserID, TeamName: current.TeamName})
+			}
+		}
+		if len(conflicts) > 0 && !upd.MoveExistingMembers {
+			return &errors.MemberConflictError{Conflicts: conflicts}
+		}
+		for i, member := range upd.AddMembers {
+			current := existing[i]
+			if current == nil {
+				if err := s.users.CreateOrUpdate(txCtx, &member.User); err != nil {
+					return err
+				}
+			}
+			member.IsPrimary = member.IsPrimary || current == nil || current.TeamName == ""
+			if err := s.users.AddMembership(txCtx, teamName, member); err != nil {
+				return err
+			}
+		}
+		updated, err = s.teams.GetByName(txCtx, teamName)
+		return err
+	})
+	return updated, err
+}
+
+func (s *teamService) RenameTeam(ctx context.Context, oldName, newName string) (*entities.Team, error) {
+	var renamed *entities.Team
+	err := s.withTx(ctx, func(txCtx context.Context) error {
+		team, err := s.teams.GetByName(txCtx, oldName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return errors.ErrTeamNotFound
+		}
+		taken, err := s.teams.GetByName(txCtx, newName)
+		if err != nil {
+			return err
+		}
+		if taken != nil {
+			return errors.ErrTeamExists
+		}
+		// A concurrent rename or create can still take the name first.
+		if err := s.teams.Rename(txCtx, oldName, newName); err != nil {
+			if IsUniqueViolation(err) {
+				return errors.ErrTeamExists
+			}
+			return err
+		}
+		renamed, err = s.teams.GetByName(txCtx, newName)
+		return err
+	})
+	return renamed, err
+}
+
+// DeleteTeam removes a team. Members involved in OPEN pull requests block the
+// deletion unless targetTeam is set, in which case all members and sub-teams
+// move there. Without a target the sub-teams move up to the team's parent.
+func (s *teamService) DeleteTeam(ctx context.Context, teamName, targetTeam string) error {
+	return s.withTx(ctx, func(txCtx context.Context) error {
+		team, err := s.teams.GetByName(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return errors.ErrTeamNotFound
+		}
+		newParent := team.ParentTeam
+		if targetTeam == "" {
+			busy, err := s.teams.HasOpenPRs(txCtx, teamName)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return errors.ErrTeamHasOpenPRs
+			}
+		} else {
+			if targetTeam == teamName {
+				return errors.ErrInvalidTargetTeam
+			}
+			target, err := s.teams.GetByName(txCtx, targetTeam)
+			if err != nil {
+				return err
+			}
+			if target == nil {
+				return errors.ErrTeamNotFound
+			}
+			if err := s.teams.LockHierarchy(txCtx, teamName, targetTeam); err != nil {
+				return err
+			}
+			descendants, err := s.teams.Descendants(txCtx, teamName)
+			if err != nil {
+				return err
+			}
+			// A target below the team takes the team's place first, so the
+			// sub-teams can move under it without a cycle.
+			if containsID(descendants, targetTeam) {
+				if err := s.teams.SetParent(txCtx, targetTeam, team.ParentTeam); err != nil {
+					return err
+				}
+			}
+			newParent = targetTeam
+		}
+		if err := s.teams.MoveMembers(txCtx, teamName, targetTeam); err != nil {
+			return err
+		}
+		if err := s.teams.ReparentChildren(txCtx, teamName, newParent); err != nil {
+			return err
+		}
+		return s.teams.Delete(txCtx, teamName)
+	})
+}
+
+// setParent attaches teamName under parent, refusing any change that would
+// make a team its own ancestor. The team and the new parent's chain are locked
+// before the check, so concurrent reparenting cannot slip a cycle past it. An
+// empty parent detaches the team.
+func (s *teamService) setParent(ctx context.Context, teamName, parent string) error {
+	if parent != "" {
+		if parent == teamName {
+			return errors.ErrTeamCycle
+		}
+		p, err := s.teams.GetByName(ctx, parent)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return errors.ErrParentNotFound
+		}
+		if err := s.teams.LockHierarchy(ctx, teamName, parent); err != nil {
+			return err
+		}
+		ancestors, err := s.teams.Ancestors(ctx, parent)
+		if err != nil {
+			return err
+		}
+		for _, a := range ancestors {
+			if a == teamName {
+				return errors.ErrTeamCycle
+			}
+		}
+	}
+	return s.teams.SetParent(ctx, teamName, parent)
+}
+
+// TeamTree returns the hierarchy below root, or the whole forest when root is empty.
+func (s *teamService) TeamTree(ctx context.Context, root string) ([]entities.TeamNode, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := map[string][]string{}
+	known := map[string]bool{}
+	var roots []string
+	for _, t := range teams {
+		known[t.TeamName] = true
+		if t.ParentTeam == "" {
+			roots = append(roots, t.TeamName)
+		} else {
+			children[t.ParentTeam] = append(children[t.ParentTeam], t.TeamName)
+		}
+	}
+	if root != "" {
+		if !known[root] {
+			return nil, errors.ErrTeamNotFound
+		}
+		roots = []string{root}
+	}
+
+	var build func(name string, seen map[string]bool) entities.TeamNode
+	build = func(name string, seen map[string]bool) entities.TeamNode {
+		node := entities.TeamNode{TeamName: name}
+		seen[name] = true
+		for _, child := range children[name] {
+			if !seen[child] {
+				node.Children = append(node.Children, build(child, seen))
+			}
+		}
+		return node
+	}
+	nodes := make([]entities.TeamNode, 0, len(roots))
+	for _, r := range roots {
+		nodes = append(nodes, build(r, map[string]bool{}))
+	}
+	return nodes, nil
+}
+
+func (s *teamService) GetStats(ctx context.Context, teamName string) (*entities.TeamStats, error) {
+	team, err := s.teams.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, errors.ErrTeamNotFound
+	}
+	subteams, err := s.teams.Descendants(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	own, err := s.teams.Stats(ctx, []string{teamName})
+	if err != nil {
+		return nil, err
+	}
+	rollup, err := s.teams.Stats(ctx, append([]string{teamName}, subteams...))
+	if err != nil {
+		return nil, err
+	}
+	pairings, err := s.teams.Pairings(ctx, teamName, time.Now().Add(-team.Settings.PairingWindow()))
+	if err != nil {
+		return nil, err
+	}
+	return &entities.TeamStats{
+		TeamName:          teamName,
+		Subteams:          subteams,
+		Own:               own,
+		Rollup:            rollup,
+		PairingWindowDays: team.Settings.PairingWindowDays,
+		Pairings:          pairings,
+	}, nil
+}
@@ -30,6 +30,34 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 	return user, nil
 }
 
+func (s *userService) SetSeniority(ctx context.Context, userID string, seniority entities.Seniority) (*entities.User, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := s.users.SetSeniority(ctx, userID, seniority); err != nil {
+		return nil, err
+	}
+
+	user.Seniority = seniority
+	return user, nil
+}
+
+func (s *userService) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*entities.User, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := s.users.SetMaxOpenReviews(ctx, userID, limit); err != nil {
+		return nil, err
+	}
+
+	user.MaxOpenReviews = limit
+	return user, nil
+}
+
 func (s *userService) GetByID(ctx context.Context, userID string) (*entities.User, error) {
 	user, err := s.users.GetByID(ctx, userID)
 	if err != nil || user == nil {
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+type AssignmentDecisionRepositoryPG struct {
+	db *PG
+}
+
+func NewAssignmentDecisionRepositoryPG(db *PG) repositories.AssignmentDecisionRepository {
+	return &AssignmentDecisionRepositoryPG{db: db}
+}
+
+func (r *AssignmentDecisionRepositoryPG) querier(ctx context.Context) dbQuerier {
+	if tx, ok := TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *AssignmentDecisionRepositoryPG) Append(ctx context.Context, d *entities.AssignmentDecision) error {
+	q := `
+        INSERT INTO assignment_decisions (org_id, pr_id, reviewer_id, slot, strategy, replaced_reviewer_id,
+                                          over_capacity, candidates, excluded)
+        SELECT org_id, pr_id, $2, $3, $4, NULLIF($5, ''), $6, $7, $8
+        FROM pull_requests
+        WHERE pr_id = $1 AND org_id = $9
+        RETURNING decision_id, created_at
+    `
+	err := r.querier(ctx).QueryRow(ctx, q,
+		d.PRID, d.ReviewerID, d.Slot, d.Strategy, d.ReplacedReviewerID,
+		d.OverCapacity, d.Candidates, d.Excluded, repositories.OrgFromContext(ctx),
+	).Scan(&d.DecisionID, &d.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return errors.ErrPRNotFound
+	}
+	return err
+}
+
+func (r *AssignmentDecisionRepositoryPG) ListByPR(ctx context.Context, prID string) ([]entities.AssignmentDecision, error) {
+	q := `
+        SELECT d.decision_id, d.pr_id, d.reviewer_id, d.slot, d.strategy, COALESCE(d.replaced_reviewer_id, ''),
+               d.over_capacity, d.candidates, d.excluded, d.created_at
+        FROM assignment_decisions d
+        WHERE d.pr_id = $1 AND d.org_id = $2
+        ORDER BY d.decision_id
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, prID, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.AssignmentDecision
+	for rows.Next() {
+		var d entities.AssignmentDecision
+		if err := rows.Scan(&d.DecisionID, &d.PRID, &d.ReviewerID, &d.Slot, &d.Strategy, &d.ReplacedReviewerID,
+			&d.OverCapacity, &d.Candidates, &d.Excluded, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
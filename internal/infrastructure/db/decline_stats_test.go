@@ -0,0 +1,52 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+// TestDeclinesInStats declines a review and expects it in the reviewer's
+// counts and in the stats of the reviewer's team.
+func TestDeclinesInStats(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	team := &entities.Team{
+		TeamName: "core",
+		Settings: entities.TeamSettings{ReviewersCount: 1},
+		Members: []entities.TeamMember{
+			member("dc-author", entities.MemberRoleMember),
+			member("dc-r1", entities.MemberRoleMember),
+			member("dc-r2", entities.MemberRoleMember),
+		},
+	}
+	if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	pr, err := svc.prs.CreatePR(ctx, "dc-pr", "change", "dc-author", entities.PRSize{})
+	if err != nil {
+		t.Fatalf("create PR: %v", err)
+	}
+	declined := pr.Reviewers[0]
+	if _, _, err := svc.prs.Decline(ctx, "dc-pr", declined, "on vacation"); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+
+	page, err := svc.prs.ListByReviewer(ctx, entities.ReviewListFilter{ReviewerID: declined}, "")
+	if err != nil {
+		t.Fatalf("list reviews: %v", err)
+	}
+	if page.Counts.Declined != 1 || page.Counts.Total != 0 {
+		t.Errorf("counts of %s = %+v, want one decline and no reviews", declined, page.Counts)
+	}
+
+	stats, err := svc.teams.GetStats(ctx, "core")
+	if err != nil {
+		t.Fatalf("team stats: %v", err)
+	}
+	if stats.Own.Declines != 1 || stats.Rollup.Declines != 1 {
+		t.Errorf("team declines = %d own, %d rollup; want 1", stats.Own.Declines, stats.Rollup.Declines)
+	}
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyRepositoryPG struct {
+	db *PG
+}
+
+func NewIdempotencyRepositoryPG(db *PG) repositories.IdempotencyRepository {
+	return &IdempotencyRepositoryPG{db: db}
+}
+
+func (r *IdempotencyRepositoryPG) querier(ctx context.Context) dbQuerier {
+	if tx, ok := TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *IdempotencyRepositoryPG) Reserve(ctx context.Context, k *entities.IdempotencyKey) (bool, error) {
+	orgID := repositories.OrgFromContext(ctx)
+	if _, err := r.querier(ctx).Exec(ctx, `
+        DELETE FROM idempotency_keys
+        WHERE org_id = $1
+          AND (expires_at <= now() OR (status_code IS NULL AND lease_expires_at <= now()))
+    `, orgID); err != nil {
+		return false, err
+	}
+
+	q := `
+        INSERT INTO idempotency_keys (org_id, idempotency_key, request_hash, expires_at, lease_id, lease_expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (org_id, idempotency_key) DO NOTHING
+        RETURNING created_at
+    `
+	err := r.querier(ctx).QueryRow(ctx, q, orgID, k.Key, k.RequestHash, k.ExpiresAt, k.LeaseID, k.LeaseExpiresAt).
+		Scan(&k.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *IdempotencyRepositoryPG) Get(ctx context.Context, key string) (*entities.IdempotencyKey, error) {
+	q := `
+        SELECT idempotency_key, request_hash, COALESCE(status_code, 0), content_type,
+               COALESCE(body, ''::bytea), created_at, expires_at,
+               COALESCE(lease_id, ''), COALESCE(lease_expires_at, created_at)
+        FROM idempotency_keys
+        WHERE org_id = $1 AND idempotency_key = $2 AND expires_at > now()
+    `
+	var k entities.IdempotencyKey
+	err := r.querier(ctx).QueryRow(ctx, q, repositories.OrgFromContext(ctx), key).
+		Scan(&k.Key, &k.RequestHash, &k.StatusCode, &k.ContentType, &k.Body, &k.CreatedAt, &k.ExpiresAt,
+			&k.LeaseID, &k.LeaseExpiresAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *IdempotencyRepositoryPG) Complete(ctx context.Context, k *entities.IdempotencyKey) error {
+	q := `
+        UPDATE idempotency_keys
+        SET status_code = $4, content_type = $5, body = $6
+        WHERE org_id = $1 AND idempotency_key = $2 AND lease_id = $3 AND status_code IS NULL
+    `
+	_, err := r.querier(ctx).Exec(ctx, q,
+		repositories.OrgFromContext(ctx), k.Key, k.LeaseID, k.StatusCode, k.ContentType, k.Body)
+	return err
+}
+
+func (r *IdempotencyRepositoryPG) Delete(ctx context.Context, k *entities.IdempotencyKey) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+        DELETE FROM idempotency_keys
+        WHERE org_id = $1 AND idempotency_key = $2 AND lease_id = $3 AND status_code IS NULL
+    `, repositories.OrgFromContext(ctx), k.Key, k.LeaseID)
+	return err
+}
@@ -0,0 +1,93 @@
+package db_test
+
+import (
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+)
+
+// TestConcurrentIdempotencyClaims claims one key from several requests at
+// once. Exactly one of them gets it; the others are told the key is in use.
+func TestConcurrentIdempotencyClaims(t *testing.T) {
+	pg := testDB(t)
+	idem := services.NewIdempotencyService(db.NewIdempotencyRepositoryPG(pg), time.Hour, time.Hour)
+	ctx := newOrg(t, pg)
+
+	const requests = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := idem.Begin(ctx, "claim", "hash")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	claimed := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			claimed++
+		case !stderrors.Is(err, errors.ErrIdempotencyKeyInUse):
+			t.Errorf("claim: %v", err)
+		}
+	}
+	if claimed != 1 {
+		t.Errorf("%d requests claimed the key, want exactly 1", claimed)
+	}
+}
+
+// TestIdempotencyLeaseReclaim lets a claim outlive its lease. Another request
+// takes the key over, and the stale claim can neither store its response
+// over the new one nor release the key.
+func TestIdempotencyLeaseReclaim(t *testing.T) {
+	pg := testDB(t)
+	idem := services.NewIdempotencyService(db.NewIdempotencyRepositoryPG(pg), time.Hour, 50*time.Millisecond)
+	ctx := newOrg(t, pg)
+
+	stale, err := idem.Begin(ctx, "lease", "hash")
+	if err != nil {
+		t.Fatalf("first claim: %v", err)
+	}
+	if _, err := idem.Begin(ctx, "lease", "hash"); !stderrors.Is(err, errors.ErrIdempotencyKeyInUse) {
+		t.Fatalf("claim under a live lease: err = %v, want ErrIdempotencyKeyInUse", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	fresh, err := idem.Begin(ctx, "lease", "hash")
+	if err != nil {
+		t.Fatalf("claim after the lease: %v", err)
+	}
+	if fresh.Completed() || fresh.LeaseID == stale.LeaseID {
+		t.Fatalf("claim after the lease = %+v, want a new in-flight claim", fresh)
+	}
+
+	if err := idem.Abandon(ctx, stale); err != nil {
+		t.Fatalf("abandon the stale claim: %v", err)
+	}
+	stale.StatusCode, stale.Body = 500, []byte("stale")
+	if err := idem.Complete(ctx, stale); err != nil {
+		t.Fatalf("complete the stale claim: %v", err)
+	}
+	fresh.StatusCode, fresh.ContentType, fresh.Body = 201, "application/json", []byte("fresh")
+	if err := idem.Complete(ctx, fresh); err != nil {
+		t.Fatalf("complete the new claim: %v", err)
+	}
+
+	got, err := idem.Begin(ctx, "lease", "hash")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if got.StatusCode != 201 || string(got.Body) != "fresh" {
+		t.Errorf("replayed %d %q, want the new claim's 201 \"fresh\"", got.StatusCode, got.Body)
+	}
+}
@@ -0,0 +1,123 @@
+package db_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+func member(id string, role entities.MemberRole) entities.TeamMember {
+	return entities.TeamMember{
+		User:         entities.User{UserID: id, Username: id, IsActive: true},
+		ReviewWeight: 1,
+		Role:         role,
+	}
+}
+
+// TestMergeWaitsForQueuedLead merges a PR whose lead slot is queued because
+// the only lead is at capacity. The merge must wait for a lead's approval
+// even though no lead is assigned yet.
+func TestMergeWaitsForQueuedLead(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	team := &entities.Team{
+		TeamName: "core",
+		Settings: entities.TeamSettings{
+			ReviewersCount:     2,
+			LeadReviewMinLines: 10,
+			MaxOpenReviews:     1,
+			OverloadPolicy:     entities.OverloadQueue,
+		},
+		Members: []entities.TeamMember{
+			member("lq-author", entities.MemberRoleMember),
+			member("lq-lead", entities.MemberRoleLead),
+			member("lq-m1", entities.MemberRoleMember),
+			member("lq-m2", entities.MemberRoleMember),
+		},
+	}
+	if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	large := entities.PRSize{LinesChanged: 100}
+	first, err := svc.prs.CreatePR(ctx, "lq-pr1", "first", "lq-author", large)
+	if err != nil {
+		t.Fatalf("create first PR: %v", err)
+	}
+	if len(first.LeadReviewers) != 1 || first.LeadReviewers[0] != "lq-lead" {
+		t.Fatalf("first PR leads = %v, want [lq-lead]", first.LeadReviewers)
+	}
+	second, err := svc.prs.CreatePR(ctx, "lq-pr2", "second", "lq-author", large)
+	if err != nil {
+		t.Fatalf("create second PR: %v", err)
+	}
+	if !second.Queued || len(second.LeadReviewers) != 0 {
+		t.Fatalf("second PR queued = %v with leads %v, want a queued lead slot", second.Queued, second.LeadReviewers)
+	}
+
+	if _, err := svc.prs.Merge(ctx, "lq-pr2"); !stderrors.Is(err, errors.ErrLeadApprovalRequired) {
+		t.Fatalf("merge with a queued lead slot: err = %v, want ErrLeadApprovalRequired", err)
+	}
+
+	// The lead's approval of the first PR frees them for the second one.
+	if _, err := svc.prs.Approve(ctx, "lq-pr1", "lq-lead"); err != nil {
+		t.Fatalf("approve first PR: %v", err)
+	}
+	second, err = svc.prs.GetPR(ctx, "lq-pr2")
+	if err != nil {
+		t.Fatalf("get second PR: %v", err)
+	}
+	if len(second.LeadReviewers) != 1 || second.LeadReviewers[0] != "lq-lead" {
+		t.Fatalf("second PR leads = %v after the lead was freed, want [lq-lead]", second.LeadReviewers)
+	}
+	if _, err := svc.prs.Approve(ctx, "lq-pr2", "lq-lead"); err != nil {
+		t.Fatalf("approve second PR: %v", err)
+	}
+	if _, err := svc.prs.Merge(ctx, "lq-pr2"); err != nil {
+		t.Fatalf("merge after the lead approved: %v", err)
+	}
+}
+
+// TestDeleteTeamKeepsMemberRoles deletes a team into another one; its lead
+// must stay a lead in the target team.
+func TestDeleteTeamKeepsMemberRoles(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	for _, team := range []*entities.Team{
+		{TeamName: "old", Members: []entities.TeamMember{
+			member("mv-lead", entities.MemberRoleLead),
+			member("mv-member", entities.MemberRoleMember),
+		}},
+		{TeamName: "new", Members: []entities.TeamMember{member("mv-other", entities.MemberRoleMember)}},
+	} {
+		if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+			t.Fatalf("create team %s: %v", team.TeamName, err)
+		}
+	}
+	if err := svc.teams.DeleteTeam(ctx, "old", "new"); err != nil {
+		t.Fatalf("delete team: %v", err)
+	}
+
+	team, err := svc.teams.GetTeam(ctx, "new")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	want := map[string]entities.MemberRole{
+		"mv-lead":   entities.MemberRoleLead,
+		"mv-member": entities.MemberRoleMember,
+		"mv-other":  entities.MemberRoleMember,
+	}
+	if len(team.Members) != len(want) {
+		t.Fatalf("target team has %d members, want %d", len(team.Members), len(want))
+	}
+	for _, m := range team.Members {
+		if m.Role != want[m.UserID] {
+			t.Errorf("%s has role %q in the target team, want %q", m.UserID, m.Role, want[m.UserID])
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/events"
+	"github.com/jackc/pgx/v5"
+)
+
+// The tests in this package run against a real Postgres server. TEST_DATABASE_URL
+// must name a role that may create databases and roles; each run migrates a
+// fresh database and drops it afterwards. Without the variable the tests are
+// skipped.
+const (
+	testAppUser     = "avito_test"
+	testAppPassword = "avito_test"
+)
+
+var (
+	setupOnce sync.Once
+	setupErr  error
+	adminURL  *url.URL
+	dbName    string
+	testPG    *db.PG
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testPG != nil {
+		testPG.Pool.Close()
+		if err := dropDatabase(); err != nil {
+			fmt.Fprintf(os.Stderr, "drop test database %s: %v\n", dbName, err)
+		}
+	}
+	os.Exit(code)
+}
+
+// testDB returns a pool on the migrated test database. It connects as a
+// member of avito_app, so row-level security applies as in production.
+func testDB(t *testing.T) *db.PG {
+	t.Helper()
+	raw := os.Getenv("TEST_DATABASE_URL")
+	if raw == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	setupOnce.Do(func() { setupErr = setup(raw) })
+	if setupErr != nil {
+		t.Fatalf("set up test database: %v", setupErr)
+	}
+	return testPG
+}
+
+func setup(raw string) error {
+	ctx := context.Background()
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	adminURL = u
+	dbName = fmt.Sprintf("avito_test_%d", time.Now().UnixNano())
+
+	admin, err := pgx.Connect(ctx, u.String())
+	if err != nil {
+		return err
+	}
+	_, err = admin.Exec(ctx, `CREATE DATABASE `+pgx.Identifier{dbName}.Sanitize())
+	admin.Close(ctx)
+	if err != nil {
+		return err
+	}
+
+	target := *u
+	target.Path = "/" + dbName
+	conn, err := pgx.Connect(ctx, target.String())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		sql, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Exec(ctx, string(sql)); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+	}
+
+	role := pgx.Identifier{testAppUser}.Sanitize()
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`
+        DO $$
+        BEGIN
+            IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = '%s') THEN
+                CREATE ROLE %s LOGIN;
+            END IF;
+        END
+        $$`, testAppUser, role)); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`ALTER ROLE %s PASSWORD '%s'`, role, testAppPassword)); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, `GRANT avito_app TO `+role); err != nil {
+		return err
+	}
+
+	target.User = url.UserPassword(testAppUser, testAppPassword)
+	testPG, err = db.New(target.String())
+	return err
+}
+
+func dropDatabase() error {
+	ctx := context.Background()
+	admin, err := pgx.Connect(ctx, adminURL.String())
+	if err != nil {
+		return err
+	}
+	defer admin.Close(ctx)
+	_, err = admin.Exec(ctx, `DROP DATABASE `+pgx.Identifier{dbName}.Sanitize()+` WITH (FORCE)`)
+	return err
+}
+
+// testServices wires the services over the repositories of pg the way main does.
+type testServices struct {
+	teams interfaces.TeamService
+	users interfaces.UserService
+	prs   interfaces.PRService
+}
+
+func newTestServices(pg *db.PG) testServices {
+	withTx := func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return pg.WithTx(ctx, fn)
+	}
+	readOnlyTx := func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return pg.WithReadOnlyTx(ctx, fn)
+	}
+	userRepo := db.NewUserRepositoryPG(pg)
+	teamRepo := db.NewTeamRepositoryPG(pg)
+	return testServices{
+		teams: services.NewTeamService(teamRepo, userRepo, withTx),
+		users: services.NewUserService(userRepo),
+		prs: services.NewPRService(userRepo, teamRepo, db.NewPRRepositoryPG(pg), db.NewPREventRepositoryPG(pg),
+			db.NewAssignmentDecisionRepositoryPG(pg), services.NewRandomSource(1), events.NewBroadcaster(),
+			withTx, readOnlyTx),
+	}
+}
+
+// newOrg provisions an organization of its own for the calling test and
+// returns a context acting as its admin.
+func newOrg(t *testing.T, pg *db.PG) context.Context {
+	t.Helper()
+	org := fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+	if err := db.NewOrganizationRepositoryPG(pg).Ensure(context.Background(),
+		&entities.Organization{OrgID: org, Name: org}); err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	return services.WithPrincipal(context.Background(), entities.Principal{OrgID: org, Role: entities.RoleAdmin})
+}
@@ -0,0 +1,162 @@
+package db_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+)
+
+// seedOrg creates the team "backend" with the members u1, u2 and u3 and the PR
+// "pr" by u1 in the organization of ctx. Every organization uses the same ids;
+// names start with prefix, so that the rows can be told apart.
+func seedOrg(t *testing.T, ctx context.Context, svc testServices, prefix string) {
+	t.Helper()
+	team := &entities.Team{TeamName: "backend"}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		team.Members = append(team.Members, entities.TeamMember{
+			User:         entities.User{UserID: id, Username: prefix + id, IsActive: true},
+			ReviewWeight: 1,
+			Role:         entities.MemberRoleMember,
+		})
+	}
+	if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+		t.Fatalf("create team in %s: %v", prefix, err)
+	}
+	if _, err := svc.prs.CreatePR(ctx, "pr", prefix+"feature", "u1", entities.PRSize{}); err != nil {
+		t.Fatalf("create PR in %s: %v", prefix, err)
+	}
+}
+
+func TestOrganizationsAreIsolated(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctxA := newOrg(t, pg)
+	ctxB := newOrg(t, pg)
+
+	// Both organizations may use the same team name, user ids and PR id.
+	seedOrg(t, ctxA, svc, "a-")
+	seedOrg(t, ctxB, svc, "b-")
+
+	t.Run("same ids", func(t *testing.T) {
+		if _, err := svc.prs.CreatePR(ctxA, "pr", "again", "u1", entities.PRSize{}); !stderrors.Is(err, errors.ErrPRExists) {
+			t.Errorf("create a PR id taken in the same organization: err = %v, want ErrPRExists", err)
+		}
+		u, err := svc.users.GetByID(ctxB, "u1")
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		if u.Username != "b-u1" || u.TeamName != "backend" {
+			t.Errorf("org B's u1 = %+v, want b-u1 in backend", u)
+		}
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		team, err := svc.teams.GetTeam(ctxA, "backend")
+		if err != nil {
+			t.Fatalf("get team: %v", err)
+		}
+		if len(team.Members) != 3 {
+			t.Fatalf("org A sees %d members of backend, want 3", len(team.Members))
+		}
+		for _, m := range team.Members {
+			if m.Username[:2] != "a-" {
+				t.Errorf("org A sees member %s of org B", m.Username)
+			}
+		}
+
+		teams, err := db.NewTeamRepositoryPG(pg).List(ctxA)
+		if err != nil {
+			t.Fatalf("list teams: %v", err)
+		}
+		if len(teams) != 1 {
+			t.Errorf("org A lists %d teams, want 1", len(teams))
+		}
+	})
+
+	t.Run("users", func(t *testing.T) {
+		if _, err := svc.users.SetIsActive(ctxA, "u3", false); err != nil {
+			t.Fatalf("deactivate: %v", err)
+		}
+		u, err := db.NewUserRepositoryPG(pg).GetByID(ctxB, "u3")
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		if u == nil || !u.IsActive || u.Username != "b-u3" {
+			t.Errorf("org B's u3 = %+v after org A deactivated its own, want it active", u)
+		}
+	})
+
+	t.Run("pull requests", func(t *testing.T) {
+		before, err := svc.prs.GetPR(ctxB, "pr")
+		if err != nil {
+			t.Fatalf("get PR: %v", err)
+		}
+		prA, err := svc.prs.GetPR(ctxA, "pr")
+		if err != nil {
+			t.Fatalf("get PR: %v", err)
+		}
+		if prA.Name != "a-feature" {
+			t.Fatalf("org A's PR is %q, want a-feature", prA.Name)
+		}
+		if _, err := svc.prs.Approve(ctxA, "pr", prA.Reviewers[0]); err != nil {
+			t.Fatalf("approve: %v", err)
+		}
+		if _, err := svc.prs.Close(ctxA, "pr"); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+
+		after, err := svc.prs.GetPR(ctxB, "pr")
+		if err != nil {
+			t.Fatalf("get PR: %v", err)
+		}
+		if after.Status != entities.PRStatusOpen || len(after.Approvals) != 0 || after.Version != before.Version {
+			t.Errorf("org B's PR = %+v after org A approved and closed its own, want it unchanged from %+v", after, before)
+		}
+		events, err := svc.prs.History(ctxB, "pr")
+		if err != nil {
+			t.Fatalf("history: %v", err)
+		}
+		for _, e := range events {
+			if e.Type == entities.PREventReviewed || e.Type == entities.PREventClosed {
+				t.Errorf("org B's history has org A's %s event", e.Type)
+			}
+		}
+	})
+
+	// Row-level security holds even for queries without an org_id filter.
+	t.Run("row-level security", func(t *testing.T) {
+		orgA := repositories.OrgFromContext(ctxA)
+		for _, table := range []string{"teams", "team_memberships", "users", "pull_requests",
+			"pull_request_reviewers", "pull_request_declines", "pr_events", "assignment_decisions"} {
+			var n int
+			if err := pg.Pool.QueryRow(ctxA, `SELECT COUNT(*) FROM `+table+` WHERE org_id <> $1`, orgA).
+				Scan(&n); err != nil {
+				t.Fatalf("count %s: %v", table, err)
+			}
+			if n != 0 {
+				t.Errorf("org A sees %d rows of other organizations in %s", n, table)
+			}
+		}
+
+		var n int
+		if err := pg.Pool.QueryRow(ctxA, `SELECT COUNT(*) FROM users`).Scan(&n); err != nil {
+			t.Fatalf("count users: %v", err)
+		}
+		if n != 3 {
+			t.Errorf("org A sees %d users, want 3", n)
+		}
+
+		if err := pg.Pool.QueryRow(repositories.WithOrg(context.Background(), ""),
+			`SELECT COUNT(*) FROM teams`).Scan(&n); err != nil {
+			t.Fatalf("count teams: %v", err)
+		}
+		if n != 0 {
+			t.Errorf("a connection without an organization sees %d teams", n)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+)
+
+type OrganizationRepositoryPG struct {
+	db *PG
+}
+
+func NewOrganizationRepositoryPG(db *PG) repositories.OrganizationRepository {
+	return &OrganizationRepositoryPG{db: db}
+}
+
+func (r *OrganizationRepositoryPG) querier(ctx context.Context) dbQuerier {
+	if tx, ok := TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *OrganizationRepositoryPG) Ensure(ctx context.Context, org *entities.Organization) error {
+	q := `
+        INSERT INTO organizations (org_id, name)
+        VALUES ($1, $2)
+        ON CONFLICT (org_id) DO NOTHING
+    `
+	_, err := r.querier(ctx).Exec(ctx, q, org.OrgID, org.Name)
+	return err
+}
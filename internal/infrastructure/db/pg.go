@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -14,12 +15,28 @@ type PG struct {
 }
 
 func New(conn string) (*PG, error) {
-	pool, err := pgxpool.New(context.Background(), conn)
+	cfg, err := pgxpool.ParseConfig(conn)
+	if err != nil {
+		return nil, err
+	}
+	cfg.PrepareConn = setOrg
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &PG{Pool: pool}, nil
 }
+
+// setOrg binds a connection taken from the pool to the organization of the
+// acquiring context, which the row-level security policies check. Connections
+// acquired without an organization see no tenant data.
+func setOrg(ctx context.Context, conn *pgx.Conn) (bool, error) {
+	if _, err := conn.Exec(ctx, `SELECT set_config('app.org_id', $1, false)`,
+		repositories.OrgFromContext(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 func (pg *PG) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
 	tx, err := pg.Pool.Begin(ctx)
 	if err != nil {
@@ -36,6 +53,17 @@ func (pg *PG) WithTx(ctx context.Context, fn func(ctx context.Context) error) er
 	return tx.Commit(ctx)
 }
 
+// WithReadOnlyTx runs fn in a read-only transaction that is always rolled back.
+func (pg *PG) WithReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := pg.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
+
 func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
 	v := ctx.Value(txKey{})
 	if v == nil {
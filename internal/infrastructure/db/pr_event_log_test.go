@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+)
+
+// TestEventLogWaitsForEarlierTransactions commits an event while a
+// transaction holding a lower event id is still open. The later event must
+// not be handed out before the earlier one, or a reader resuming after it
+// would never see the earlier event.
+func TestEventLogWaitsForEarlierTransactions(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+	events := db.NewPREventRepositoryPG(pg)
+
+	seedOrg(t, ctx, svc, "log-")
+	base, err := svc.prs.LastEventID(ctx)
+	if err != nil {
+		t.Fatalf("last event id: %v", err)
+	}
+
+	appended := make(chan int64)
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- pg.WithTx(ctx, func(txCtx context.Context) error {
+			e := &entities.PREvent{PRID: "log-pr", Type: entities.PREventReviewed, ActorID: "log-u2"}
+			if err := events.Append(txCtx, e); err != nil {
+				close(appended)
+				return err
+			}
+			appended <- e.EventID
+			<-release
+			return nil
+		})
+	}()
+	early, ok := <-appended
+	if !ok {
+		t.Fatalf("append in the open transaction: %v", <-done)
+	}
+
+	late := &entities.PREvent{PRID: "log-pr", Type: entities.PREventReviewed, ActorID: "log-u3"}
+	if err := pg.WithTx(ctx, func(txCtx context.Context) error {
+		return events.Append(txCtx, late)
+	}); err != nil {
+		close(release)
+		t.Fatalf("append: %v", err)
+	}
+	if late.EventID <= early {
+		close(release)
+		t.Fatalf("late event %d numbered before early event %d", late.EventID, early)
+	}
+
+	page, err := svc.prs.EventsSince(ctx, entities.PREventFilter{AfterID: base, Limit: 100})
+	if err != nil {
+		close(release)
+		t.Fatalf("events since %d: %v", base, err)
+	}
+	if len(page.Events) != 0 || page.SettledID >= early {
+		t.Errorf("read %d events settled up to %d while event %d is uncommitted", len(page.Events), page.SettledID, early)
+	}
+	if !page.Pending {
+		t.Errorf("committed event %d is not reported as pending", late.EventID)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("commit the open transaction: %v", err)
+	}
+
+	// Transactions of other clients of the server may delay settling a bit.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		page, err = svc.prs.EventsSince(ctx, entities.PREventFilter{AfterID: base, Limit: 100})
+		if err != nil {
+			t.Fatalf("events since %d: %v", base, err)
+		}
+		if !page.Pending || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	var ids []int64
+	for _, e := range page.Events {
+		ids = append(ids, e.EventID)
+	}
+	if len(ids) != 2 || ids[0] != early || ids[1] != late.EventID {
+		t.Errorf("events after both commits = %v, want [%d %d]", ids, early, late.EventID)
+	}
+	if page.Pending || page.SettledID != late.EventID {
+		t.Errorf("settled up to %d, pending %v; want %d and nothing pending", page.SettledID, page.Pending, late.EventID)
+	}
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+type PREventRepositoryPG struct {
+	db *PG
+}
+
+func NewPREventRepositoryPG(db *PG) repositories.PREventRepository {
+	return &PREventRepositoryPG{db: db}
+}
+
+func (r *PREventRepositoryPG) querier(ctx context.Context) dbQuerier {
+	if tx, ok := TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *PREventRepositoryPG) Append(ctx context.Context, e *entities.PREvent) error {
+	q := `
+        INSERT INTO pr_events (org_id, pr_id, event_type, old_reviewer_id, new_reviewer_id, actor_id, reason)
+        SELECT org_id, pr_id, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, '')
+        FROM pull_requests
+        WHERE pr_id = $1 AND org_id = $7
+        RETURNING event_id, created_at
+    `
+	err := r.querier(ctx).QueryRow(ctx, q,
+		e.PRID, e.Type, e.OldReviewerID, e.NewReviewerID, e.ActorID, e.Reason, repositories.OrgFromContext(ctx),
+	).Scan(&e.EventID, &e.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return errors.ErrPRNotFound
+	}
+	return err
+}
+
+func (r *PREventRepositoryPG) ListByPR(ctx context.Context, prID string) ([]entities.PREvent, error) {
+	q := `
+        SELECT e.event_id, e.pr_id, e.event_type,
+               COALESCE(e.old_reviewer_id, ''), COALESCE(e.new_reviewer_id, ''),
+               COALESCE(e.actor_id, ''), COALESCE(e.reason, ''), e.created_at
+        FROM pr_events e
+        WHERE e.pr_id = $1 AND e.org_id = $2
+        ORDER BY e.event_id
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, prID, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.PREvent
+	for rows.Next() {
+		var e entities.PREvent
+		if err := rows.Scan(&e.EventID, &e.PRID, &e.Type, &e.OldReviewerID, &e.NewReviewerID,
+			&e.ActorID, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
+
+// settledBelow is the condition under which an event is settled: every
+// transaction that could still number an event below it has ended.
+const settledBelow = `e.xid_horizon <= pg_snapshot_xmin(pg_current_snapshot())`
+
+func (r *PREventRepositoryPG) ListSince(ctx context.Context, f entities.PREventFilter) (*entities.PREventPage, error) {
+	org := repositories.OrgFromContext(ctx)
+	page := &entities.PREventPage{SettledID: f.AfterID}
+	qMark := `
+        WITH recent AS (
+            SELECT e.event_id, ` + settledBelow + ` AS settled
+            FROM pr_events e
+            WHERE e.org_id = $1 AND e.event_id > $2
+        ),
+        mark AS (
+            SELECT COALESCE(MAX(event_id) FILTER (WHERE settled), $2) AS id FROM recent
+        )
+        SELECT mark.id, EXISTS (SELECT 1 FROM recent WHERE recent.event_id > mark.id)
+        FROM mark
+    `
+	if err := r.querier(ctx).QueryRow(ctx, qMark, org, f.AfterID).Scan(&page.SettledID, &page.Pending); err != nil {
+		return nil, err
+	}
+	if page.SettledID == f.AfterID {
+		return page, nil
+	}
+
+	q := `
+        SELECT e.event_id, e.pr_id, e.event_type,
+               COALESCE(e.old_reviewer_id, ''), COALESCE(e.new_reviewer_id, ''),
+               COALESCE(e.actor_id, ''), COALESCE(e.reason, ''), e.created_at
+        FROM pr_events e
+        JOIN pull_requests pr ON pr.org_id = e.org_id AND pr.pr_id = e.pr_id
+        WHERE e.org_id = $1 AND e.event_id > $2 AND e.event_id <= $3
+          AND (cardinality($4::text[]) = 0 OR e.event_type = ANY($4))
+          AND ($5 = '' OR EXISTS (
+                SELECT 1 FROM team_memberships am
+                WHERE am.user_id = pr.author_id AND am.team_name = $5 AND am.org_id = $1))
+          AND ($6 = '' OR $6 IN (pr.author_id, e.old_reviewer_id, e.new_reviewer_id, e.actor_id))
+        ORDER BY e.event_id
+        LIMIT $7
+    `
+	types := make([]string, 0, len(f.Types))
+	for _, t := range f.Types {
+		types = append(types, string(t))
+	}
+	rows, err := r.querier(ctx).Query(ctx, q,
+		org, f.AfterID, page.SettledID, types, f.TeamName, f.UserID, f.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e entities.PREvent
+		if err := rows.Scan(&e.EventID, &e.PRID, &e.Type, &e.OldReviewerID, &e.NewReviewerID,
+			&e.ActorID, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		page.Events = append(page.Events, e)
+	}
+	return page, rows.Err()
+}
+
+func (r *PREventRepositoryPG) SettledID(ctx context.Context) (int64, error) {
+	q := `
+        SELECT e.event_id
+        FROM pr_events e
+        WHERE e.org_id = $1 AND ` + settledBelow + `
+        ORDER BY e.event_id DESC
+        LIMIT 1
+    `
+	var id int64
+	err := r.querier(ctx).QueryRow(ctx, q, repositories.OrgFromContext(ctx)).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
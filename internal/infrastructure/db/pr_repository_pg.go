@@ -2,7 +2,10 @@ package db
 
 import (
 	"context"
+	"time"
+
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
 	"github.com/f4ke-n0name/avito/internal/domain/repositories"
 	"github.com/jackc/pgx/v5"
 )
@@ -24,21 +27,34 @@ func (r *PRRepositoryPG) querier(ctx context.Context) dbQuerier {
 
 func (r *PRRepositoryPG) Create(ctx context.Context, pr *entities.PullRequest) error {
 	q := `
-        INSERT INTO pull_requests (pr_id, pr_name, author_id, status)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO pull_requests (pr_id, pr_name, author_id, status, lines_changed, files_changed, org_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING created_at, version
     `
-	_, err := r.querier(ctx).Exec(ctx, q, pr.PRID, pr.Name, pr.AuthorID, pr.Status)
+	err := r.querier(ctx).QueryRow(ctx, q, pr.PRID, pr.Name, pr.AuthorID, pr.Status, pr.LinesChanged, pr.FilesChanged,
+		repositories.OrgFromContext(ctx)).
+		Scan(&pr.CreatedAt, &pr.Version)
 	return err
 }
 
 func (r *PRRepositoryPG) GetByID(ctx context.Context, id string) (*entities.PullRequest, error) {
+	return r.get(ctx, id, "")
+}
+
+func (r *PRRepositoryPG) GetForUpdate(ctx context.Context, id string) (*entities.PullRequest, error) {
+	return r.get(ctx, id, "FOR UPDATE")
+}
+
+func (r *PRRepositoryPG) get(ctx context.Context, id, lock string) (*entities.PullRequest, error) {
 	pr := &entities.PullRequest{}
 	q := `
-        SELECT pr_id, pr_name, author_id, status, created_at, merged_at
+        SELECT pr_id, pr_name, author_id, status, created_at, merged_at, lines_changed, files_changed,
+               overloaded, queued, version
         FROM pull_requests
-        WHERE pr_id = $1
-    `
-	err := r.querier(ctx).QueryRow(ctx, q, id).Scan(&pr.PRID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+        WHERE pr_id = $1 AND org_id = $2
+        ` + lock
+	err := r.querier(ctx).QueryRow(ctx, q, id, repositories.OrgFromContext(ctx)).Scan(&pr.PRID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.LinesChanged, &pr.FilesChanged, &pr.Overloaded, &pr.Queued, &pr.Version)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
@@ -47,87 +63,320 @@ func (r *PRRepositoryPG) GetByID(ctx context.Context, id string) (*entities.Pull
 	}
 
 	q2 := `
-        SELECT reviewer_id
+        SELECT reviewer_id, slot, approved_at IS NOT NULL
         FROM pull_request_reviewers
-        WHERE pr_id = $1
+        WHERE pr_id = $1 AND org_id = $2
         ORDER BY assigned_at
     `
-	rows, err := r.querier(ctx).Query(ctx, q2, id)
+	rows, err := r.querier(ctx).Query(ctx, q2, id, repositories.OrgFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var reviewer string
-		_ = rows.Scan(&reviewer)
+		var slot entities.ReviewerSlot
+		var approved bool
+		if err := rows.Scan(&reviewer, &slot, &approved); err != nil {
+			return nil, err
+		}
 		pr.Reviewers = append(pr.Reviewers, reviewer)
+		switch slot {
+		case entities.ReviewerSlotLead:
+			pr.LeadReviewers = append(pr.LeadReviewers, reviewer)
+		case entities.ReviewerSlotShadow:
+			pr.ShadowReviewers = append(pr.ShadowReviewers, reviewer)
+		}
+		if approved {
+			pr.Approvals = append(pr.Approvals, reviewer)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 	return pr, nil
 }
 
-func (r *PRRepositoryPG) ListByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequest, error) {
+func (r *PRRepositoryPG) ListByReviewer(ctx context.Context, f entities.ReviewListFilter) ([]entities.PullRequest, error) {
+	var b whereBuilder
+	b.add("pr.org_id = ?", repositories.OrgFromContext(ctx))
+	b.add("me.reviewer_id = ?", f.ReviewerID)
+	if f.Status != "" {
+		b.add("pr.status = ?", f.Status)
+	}
+	if f.OnlyPending {
+		b.add("me.approved_at IS NULL")
+	}
+	if f.After != nil {
+		b.add("(pr.created_at, pr.pr_id) < (?, ?)", f.After.CreatedAt, f.After.PRID)
+	}
+
 	q := `
-        SELECT pr.pr_id, pr.pr_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
-               ARRAY_AGG(rr.reviewer_id) AS reviewers
-        FROM pull_requests pr
-        JOIN pull_request_reviewers rr ON rr.pr_id = pr.pr_id
-        WHERE pr.pr_id IN (
-            SELECT pr_id FROM pull_request_reviewers WHERE reviewer_id = $1
-        )
-        GROUP BY pr.pr_id
-        ORDER BY pr.created_at DESC
+        SELECT ` + prColumns + `
+        FROM pull_request_reviewers me
+        JOIN pull_requests pr ON pr.org_id = me.org_id AND pr.pr_id = me.pr_id
+        ` + b.where() + `
+        ORDER BY pr.created_at DESC, pr.pr_id DESC`
+	if !f.All {
+		q += `
+        LIMIT ` + b.arg(f.Limit)
+	}
+
+	rows, err := r.querier(ctx).Query(ctx, q, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanPullRequests(rows)
+}
+
+func (r *PRRepositoryPG) CountByReviewer(ctx context.Context, reviewerID string) (entities.ReviewCounts, error) {
+	q := `
+        SELECT COUNT(*),
+               COUNT(*) FILTER (WHERE pr.status = 'OPEN'),
+               COUNT(*) FILTER (WHERE pr.status = 'MERGED'),
+               COUNT(*) FILTER (WHERE pr.status = 'CLOSED'),
+               COUNT(*) FILTER (WHERE me.approved_at IS NOT NULL),
+               (SELECT COUNT(*) FROM pull_request_declines d WHERE d.reviewer_id = $1 AND d.org_id = $2)
+        FROM pull_request_reviewers me
+        JOIN pull_requests pr ON pr.org_id = me.org_id AND pr.pr_id = me.pr_id
+        WHERE me.reviewer_id = $1 AND me.org_id = $2
     `
-	rows, err := r.querier(ctx).Query(ctx, q, reviewerID)
+	var c entities.ReviewCounts
+	err := r.querier(ctx).QueryRow(ctx, q, reviewerID, repositories.OrgFromContext(ctx)).
+		Scan(&c.Total, &c.Open, &c.Merged, &c.Closed, &c.Approved, &c.Declined)
+	return c, err
+}
+
+func (r *PRRepositoryPG) Search(ctx context.Context, f entities.PRSearchFilter) ([]entities.PullRequest, error) {
+	var b whereBuilder
+	b.add("pr.org_id = ?", repositories.OrgFromContext(ctx))
+	if f.AuthorID != "" {
+		b.add("pr.author_id = ?", f.AuthorID)
+	}
+	if f.ReviewerID != "" {
+		b.add("EXISTS (SELECT 1 FROM pull_request_reviewers rr WHERE rr.org_id = pr.org_id AND rr.pr_id = pr.pr_id AND rr.reviewer_id = ?)", f.ReviewerID)
+	}
+	if f.TeamName != "" {
+		b.add("EXISTS (SELECT 1 FROM team_memberships am WHERE am.org_id = pr.org_id AND am.user_id = pr.author_id AND am.team_name = ?)", f.TeamName)
+	}
+	if f.Status != "" {
+		b.add("pr.status = ?", f.Status)
+	}
+	if f.CreatedFrom != nil {
+		b.add("pr.created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		b.add("pr.created_at < ?", *f.CreatedTo)
+	}
+	if f.MergedFrom != nil {
+		b.add("pr.merged_at >= ?", *f.MergedFrom)
+	}
+	if f.MergedTo != nil {
+		b.add("pr.merged_at < ?", *f.MergedTo)
+	}
+	if f.After != nil {
+		b.add("(pr.created_at, pr.pr_id) < (?, ?)", f.After.CreatedAt, f.After.PRID)
+	}
+
+	q := `
+        SELECT ` + prColumns + `
+        FROM pull_requests pr
+        ` + b.where() + `
+        ORDER BY pr.created_at DESC, pr.pr_id DESC
+        LIMIT ` + b.arg(f.Limit)
+
+	rows, err := r.querier(ctx).Query(ctx, q, b.args...)
 	if err != nil {
 		return nil, err
 	}
+	return scanPullRequests(rows)
+}
+
+// prColumns selects a pull request aliased as pr together with its reviewers and
+// approvals, in the order expected by scanPullRequests.
+const prColumns = `pr.pr_id, pr.pr_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+               pr.lines_changed, pr.files_changed, pr.overloaded, pr.queued, pr.version,
+               ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr
+                     WHERE rr.org_id = pr.org_id AND rr.pr_id = pr.pr_id
+                     ORDER BY rr.assigned_at) AS reviewers,
+               ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr
+                     WHERE rr.org_id = pr.org_id AND rr.pr_id = pr.pr_id AND rr.slot = 'lead'
+                     ORDER BY rr.assigned_at) AS lead_reviewers,
+               ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr
+                     WHERE rr.org_id = pr.org_id AND rr.pr_id = pr.pr_id AND rr.slot = 'shadow'
+                     ORDER BY rr.assigned_at) AS shadow_reviewers,
+               ARRAY(SELECT rr.reviewer_id FROM pull_request_reviewers rr
+                     WHERE rr.org_id = pr.org_id AND rr.pr_id = pr.pr_id AND rr.approved_at IS NOT NULL
+                     ORDER BY rr.assigned_at) AS approvals`
+
+func scanPullRequests(rows pgx.Rows) ([]entities.PullRequest, error) {
 	defer rows.Close()
 
 	var result []entities.PullRequest
 	for rows.Next() {
 		var pr entities.PullRequest
-		var reviewers []string
-		if err := rows.Scan(&pr.PRID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &reviewers); err != nil {
+		if err := rows.Scan(&pr.PRID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+			&pr.LinesChanged, &pr.FilesChanged, &pr.Overloaded, &pr.Queued, &pr.Version, &pr.Reviewers, &pr.LeadReviewers, &pr.ShadowReviewers, &pr.Approvals); err != nil {
 			return nil, err
 		}
-		pr.Reviewers = reviewers
 		result = append(result, pr)
 	}
-
-	return result, nil
+	return result, rows.Err()
 }
 
-func (r *PRRepositoryPG) AssignReviewers(ctx context.Context, prID string, reviewers []string) error {
+func (r *PRRepositoryPG) AssignReviewers(ctx context.Context, prID string, reviewers []entities.ReviewerAssignment) error {
 	q := `
-        INSERT INTO pull_request_reviewers (pr_id, reviewer_id)
-        VALUES ($1, $2)
+        INSERT INTO pull_request_reviewers (org_id, pr_id, reviewer_id, slot)
+        SELECT pr.org_id, pr.pr_id, u.user_id, $3
+        FROM pull_requests pr, users u
+        WHERE pr.pr_id = $1 AND pr.org_id = $4 AND u.user_id = $2 AND u.org_id = $4
         ON CONFLICT DO NOTHING
     `
-	for _, reviewerID := range reviewers {
-		if _, err := r.querier(ctx).Exec(ctx, q, prID, reviewerID); err != nil {
+	org := repositories.OrgFromContext(ctx)
+	for _, a := range reviewers {
+		if _, err := r.querier(ctx).Exec(ctx, q, prID, a.ReviewerID, a.Slot, org); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// ReplaceReviewer swaps oldID for newID in place; the new reviewer takes over
+// the slot of the old one, so the number of reviewers stays the same.
 func (r *PRRepositoryPG) ReplaceReviewer(ctx context.Context, prID string, oldID, newID string) error {
-	if _, err := r.querier(ctx).Exec(ctx,
-		`DELETE FROM pull_request_reviewers WHERE pr_id = $1 AND reviewer_id = $2`,
-		prID, oldID); err != nil {
+	tag, err := r.querier(ctx).Exec(ctx, `
+        UPDATE pull_request_reviewers rr
+        SET reviewer_id = u.user_id, assigned_at = now(), approved_at = NULL
+        FROM users u
+        WHERE rr.pr_id = $1 AND rr.reviewer_id = $2 AND rr.org_id = $4
+          AND u.user_id = $3 AND u.org_id = rr.org_id
+    `, prID, oldID, newID, repositories.OrgFromContext(ctx))
+	if err != nil {
 		return err
 	}
-	if _, err := r.querier(ctx).Exec(ctx,
-		`INSERT INTO pull_request_reviewers (pr_id, reviewer_id) VALUES ($1, $2)`,
-		prID, newID); err != nil {
-		return err
+	if tag.RowsAffected() == 0 {
+		return errors.ErrUserNotFound
 	}
 	return nil
 }
 
 func (r *PRRepositoryPG) MarkMerged(ctx context.Context, prID string) error {
 	_, err := r.querier(ctx).Exec(ctx,
-		`UPDATE pull_requests SET status='MERGED', merged_at=now() WHERE pr_id=$1`,
-		prID)
+		`UPDATE pull_requests SET status='MERGED', merged_at=now(), queued=FALSE WHERE pr_id=$1 AND org_id=$2`,
+		prID, repositories.OrgFromContext(ctx))
+	return err
+}
+
+func (r *PRRepositoryPG) MarkClosed(ctx context.Context, prID string) error {
+	_, err := r.querier(ctx).Exec(ctx,
+		`UPDATE pull_requests SET status='CLOSED', queued=FALSE WHERE pr_id=$1 AND org_id=$2`,
+		prID, repositories.OrgFromContext(ctx))
+	return err
+}
+
+func (r *PRRepositoryPG) MarkApproved(ctx context.Context, prID, reviewerID string) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+        UPDATE pull_request_reviewers SET approved_at = now()
+        WHERE pr_id = $1 AND reviewer_id = $2 AND org_id = $3 AND approved_at IS NULL
+    `, prID, reviewerID, repositories.OrgFromContext(ctx))
 	return err
 }
+
+func (r *PRRepositoryPG) RecordDecline(ctx context.Context, prID, reviewerID, reason string) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+        INSERT INTO pull_request_declines (org_id, pr_id, reviewer_id, reason)
+        SELECT org_id, pr_id, $2, $3 FROM pull_requests WHERE pr_id = $1 AND org_id = $4
+    `, prID, reviewerID, reason, repositories.OrgFromContext(ctx))
+	return err
+}
+
+func (r *PRRepositoryPG) ListDeclined(ctx context.Context, prID string) ([]string, error) {
+	rows, err := r.querier(ctx).Query(ctx, `
+        SELECT reviewer_id
+        FROM pull_request_declines
+        WHERE pr_id = $1 AND org_id = $2
+    `, prID, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var reviewer string
+		if err := rows.Scan(&reviewer); err != nil {
+			return nil, err
+		}
+		result = append(result, reviewer)
+	}
+	return result, nil
+}
+
+// CountPairings returns, per reviewer, how many times they were assigned to
+// PRs of the author since the given time.
+func (r *PRRepositoryPG) CountPairings(ctx context.Context, authorID string, since time.Time) (map[string]int, error) {
+	q := `
+        SELECT rr.reviewer_id, COUNT(*)
+        FROM pull_request_reviewers rr
+        JOIN pull_requests pr ON pr.org_id = rr.org_id AND pr.pr_id = rr.pr_id
+        WHERE pr.author_id = $1 AND pr.org_id = $3 AND rr.assigned_at >= $2
+        GROUP BY rr.reviewer_id
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, authorID, since, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[string]int{}
+	for rows.Next() {
+		var reviewer string
+		var n int
+		if err := rows.Scan(&reviewer, &n); err != nil {
+			return nil, err
+		}
+		result[reviewer] = n
+	}
+	return result, rows.Err()
+}
+
+// MarkOverloaded flags a PR whose reviewers were assigned beyond their capacity.
+func (r *PRRepositoryPG) MarkOverloaded(ctx context.Context, prID string) error {
+	_, err := r.querier(ctx).Exec(ctx, `UPDATE pull_requests SET overloaded = TRUE WHERE pr_id = $1 AND org_id = $2`,
+		prID, repositories.OrgFromContext(ctx))
+	return err
+}
+
+func (r *PRRepositoryPG) SetQueued(ctx context.Context, prID string, queued bool) error {
+	_, err := r.querier(ctx).Exec(ctx, `UPDATE pull_requests SET queued = $2 WHERE pr_id = $1 AND org_id = $3`,
+		prID, queued, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// LockQueued locks the open PRs waiting for reviewer capacity and returns
+// their ids, oldest first. PRs locked by other transactions are skipped
+// rather than waited for: their holders may be waiting for this transaction.
+func (r *PRRepositoryPG) LockQueued(ctx context.Context) ([]string, error) {
+	q := `
+        SELECT pr_id
+        FROM pull_requests
+        WHERE org_id = $1 AND queued AND status = 'OPEN'
+        ORDER BY created_at, pr_id
+        FOR UPDATE SKIP LOCKED
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,63 @@
+package db_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+)
+
+// TestSearchPagesThroughTies pages through PRs created at the same instant.
+// The keyset falls back to the PR id, so every PR appears exactly once, in
+// descending id order.
+func TestSearchPagesThroughTies(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	team := &entities.Team{
+		TeamName: "core",
+		Members: []entities.TeamMember{
+			member("st-author", entities.MemberRoleMember),
+			member("st-r1", entities.MemberRoleMember),
+			member("st-r2", entities.MemberRoleMember),
+		},
+	}
+	if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	var want []string
+	for i := 5; i >= 1; i-- {
+		id := fmt.Sprintf("st-pr%d", i)
+		if _, err := svc.prs.CreatePR(ctx, id, "change", "st-author", entities.PRSize{}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+		want = append(want, id)
+	}
+	if _, err := pg.Pool.Exec(ctx, `UPDATE pull_requests SET created_at = '2025-03-01T12:00:00Z'`); err != nil {
+		t.Fatalf("align creation times: %v", err)
+	}
+
+	var got []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatalf("search does not end; seen %v", got)
+		}
+		page, err := svc.prs.Search(ctx, entities.PRSearchFilter{AuthorID: "st-author", Limit: 2}, cursor)
+		if err != nil {
+			t.Fatalf("search after %q: %v", cursor, err)
+		}
+		for _, pr := range page.PullRequests {
+			got = append(got, pr.PRID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// whereBuilder collects SQL conditions together with their positional arguments.
+// Each "?" in a condition is replaced with the next $N placeholder.
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+func (b *whereBuilder) add(cond string, args ...any) {
+	for _, arg := range args {
+		b.args = append(b.args, arg)
+		cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(b.args)), 1)
+	}
+	b.conds = append(b.conds, cond)
+}
+
+// arg registers a standalone argument, e.g. for LIMIT, and returns its placeholder.
+func (b *whereBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *whereBuilder) where() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
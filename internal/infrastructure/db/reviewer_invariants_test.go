@@ -0,0 +1,165 @@
+package db_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+// expectedRaceErrors are the answers a client may get when it acts on a PR
+// that another client changed in the meantime.
+var expectedRaceErrors = []error{
+	errors.ErrNoSuchReviewer,
+	errors.ErrPRAlreadyMerged,
+	errors.ErrPRClosed,
+	errors.ErrNoCandidates,
+	errors.ErrReviewersAtCapacity,
+}
+
+func isExpectedRaceError(err error) bool {
+	for _, e := range expectedRaceErrors {
+		if stderrors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestReviewerInvariantsUnderConcurrency reassigns, approves, merges and
+// closes PRs from parallel clients. Capacity is tight, so approvals and
+// finished PRs keep handing reviewers to queued PRs. Afterwards no PR may
+// have more reviewers than its team asks for, no reviewer may have changed
+// after a merge and no author may review their own PR.
+func TestReviewerInvariantsUnderConcurrency(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	const (
+		members        = 8
+		pullRequests   = 12
+		workers        = 8
+		opsPerWorker   = 40
+		reviewersCount = 2
+	)
+	team := &entities.Team{
+		TeamName: "core",
+		Settings: entities.TeamSettings{
+			ReviewersCount: reviewersCount,
+			MaxOpenReviews: 1,
+			OverloadPolicy: entities.OverloadQueue,
+		},
+	}
+	for i := 0; i < members; i++ {
+		id := fmt.Sprintf("inv-u%d", i)
+		team.Members = append(team.Members, entities.TeamMember{
+			User:         entities.User{UserID: id, Username: id, IsActive: true},
+			ReviewWeight: 1,
+			Role:         entities.MemberRoleMember,
+		})
+	}
+	if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	var ids []string
+	for i := 0; i < pullRequests; i++ {
+		id := fmt.Sprintf("inv-pr%d", i)
+		author := fmt.Sprintf("inv-u%d", i%members)
+		if _, err := svc.prs.CreatePR(ctx, id, id, author, entities.PRSize{}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+		ids = append(ids, id)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < opsPerWorker; i++ {
+				prID := ids[rnd.Intn(len(ids))]
+				pr, err := svc.prs.GetPR(ctx, prID)
+				if err != nil {
+					t.Errorf("get %s: %v", prID, err)
+					return
+				}
+				reviewer := ""
+				if len(pr.Reviewers) > 0 {
+					reviewer = pr.Reviewers[rnd.Intn(len(pr.Reviewers))]
+				}
+				var op string
+				switch n := rnd.Intn(10); {
+				case n < 4 && reviewer != "":
+					op = "reassign"
+					_, _, err = svc.prs.ReplaceReviewer(ctx, prID, reviewer)
+				case n < 7 && reviewer != "":
+					op = "approve"
+					_, err = svc.prs.Approve(ctx, prID, reviewer)
+				case n < 9:
+					op = "merge"
+					_, err = svc.prs.Merge(ctx, prID)
+				default:
+					op = "close"
+					_, err = svc.prs.Close(ctx, prID)
+				}
+				if err != nil && !isExpectedRaceError(err) {
+					t.Errorf("%s %s: %v", op, prID, err)
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		pr, err := svc.prs.GetPR(ctx, id)
+		if err != nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		counted := 0
+		for _, r := range pr.Reviewers {
+			if r == pr.AuthorID {
+				t.Errorf("%s: author %s reviews their own PR", id, r)
+			}
+			if !containsString(pr.ShadowReviewers, r) {
+				counted++
+			}
+		}
+		if counted > reviewersCount {
+			t.Errorf("%s: %d reviewers %v, at most %d allowed", id, counted, pr.Reviewers, reviewersCount)
+		}
+
+		history, err := svc.prs.History(ctx, id)
+		if err != nil {
+			t.Fatalf("history of %s: %v", id, err)
+		}
+		merged := false
+		for _, e := range history {
+			switch e.Type {
+			case entities.PREventMerged:
+				merged = true
+			case entities.PREventReviewerAssigned, entities.PREventReviewerReplaced:
+				if merged {
+					t.Errorf("%s: event %d %s %s after the merge", id, e.EventID, e.Type, e.NewReviewerID)
+				}
+				if e.NewReviewerID == pr.AuthorID {
+					t.Errorf("%s: author %s was assigned as reviewer", id, e.NewReviewerID)
+				}
+			}
+		}
+	}
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
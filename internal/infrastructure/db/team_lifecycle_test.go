@@ -0,0 +1,159 @@
+package db_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+)
+
+// createTeams creates the teams in order, so parents come before children.
+func createTeams(t *testing.T, ctx context.Context, svc testServices, teams ...*entities.Team) {
+	t.Helper()
+	for _, team := range teams {
+		if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+			t.Fatalf("create team %s: %v", team.TeamName, err)
+		}
+	}
+}
+
+// TestRenameTeam renames a team with members and a sub-team. The members'
+// primary team and the sub-team's parent follow the new name, and a taken
+// name is refused.
+func TestRenameTeam(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	createTeams(t, ctx, svc,
+		&entities.Team{TeamName: "old", Members: []entities.TeamMember{member("rn-user", entities.MemberRoleLead)}},
+		&entities.Team{TeamName: "child", ParentTeam: "old"},
+		&entities.Team{TeamName: "taken"},
+	)
+
+	if _, err := svc.teams.RenameTeam(ctx, "old", "taken"); !stderrors.Is(err, errors.ErrTeamExists) {
+		t.Fatalf("rename to a taken name: err = %v, want ErrTeamExists", err)
+	}
+	renamed, err := svc.teams.RenameTeam(ctx, "old", "new")
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if renamed.TeamName != "new" || len(renamed.Members) != 1 || renamed.Members[0].Role != entities.MemberRoleLead {
+		t.Errorf("renamed team = %+v, want new with its lead", renamed)
+	}
+	if _, err := svc.teams.GetTeam(ctx, "old"); !stderrors.Is(err, errors.ErrTeamNotFound) {
+		t.Errorf("get old name: err = %v, want ErrTeamNotFound", err)
+	}
+	if u, err := svc.users.GetByID(ctx, "rn-user"); err != nil || u.TeamName != "new" {
+		t.Errorf("member after the rename = %+v, %v; want team new", u, err)
+	}
+	if child, err := svc.teams.GetTeam(ctx, "child"); err != nil || child.ParentTeam != "new" {
+		t.Errorf("sub-team after the rename = %+v, %v; want parent new", child, err)
+	}
+}
+
+// TestDeleteTeamWithOpenPRs deletes a team whose member authors an open PR.
+// Without a target the deletion is refused; once the PR is merged it goes
+// through and the sub-team moves up to the deleted team's parent.
+func TestDeleteTeamWithOpenPRs(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	createTeams(t, ctx, svc,
+		&entities.Team{TeamName: "root"},
+		&entities.Team{TeamName: "core", ParentTeam: "root", Settings: entities.TeamSettings{ReviewersCount: 1},
+			Members: []entities.TeamMember{
+				member("do-author", entities.MemberRoleMember),
+				member("do-reviewer", entities.MemberRoleMember),
+			}},
+		&entities.Team{TeamName: "child", ParentTeam: "core"},
+	)
+	pr, err := svc.prs.CreatePR(ctx, "do-pr", "change", "do-author", entities.PRSize{})
+	if err != nil {
+		t.Fatalf("create PR: %v", err)
+	}
+
+	if err := svc.teams.DeleteTeam(ctx, "core", ""); !stderrors.Is(err, errors.ErrTeamHasOpenPRs) {
+		t.Fatalf("delete with an open PR: err = %v, want ErrTeamHasOpenPRs", err)
+	}
+	if _, err := svc.teams.GetTeam(ctx, "core"); err != nil {
+		t.Fatalf("team after the refused delete: %v", err)
+	}
+
+	if _, err := svc.prs.Approve(ctx, "do-pr", pr.Reviewers[0]); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := svc.prs.Merge(ctx, "do-pr"); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if err := svc.teams.DeleteTeam(ctx, "core", ""); err != nil {
+		t.Fatalf("delete without open PRs: %v", err)
+	}
+	if _, err := svc.teams.GetTeam(ctx, "core"); !stderrors.Is(err, errors.ErrTeamNotFound) {
+		t.Errorf("get deleted team: err = %v, want ErrTeamNotFound", err)
+	}
+	if child, err := svc.teams.GetTeam(ctx, "child"); err != nil || child.ParentTeam != "root" {
+		t.Errorf("sub-team after the delete = %+v, %v; want parent root", child, err)
+	}
+	if u, err := svc.users.GetByID(ctx, "do-author"); err != nil || u.TeamName != "" {
+		t.Errorf("member after the delete = %+v, %v; want no team", u, err)
+	}
+}
+
+// TestDeleteTeamIntoTarget deletes a team with an open PR into another team.
+// Its members and sub-teams move to the target, also when the target was one
+// of the sub-teams.
+func TestDeleteTeamIntoTarget(t *testing.T) {
+	for _, target := range []string{"other", "child"} {
+		t.Run(target, func(t *testing.T) {
+			pg := testDB(t)
+			svc := newTestServices(pg)
+			ctx := newOrg(t, pg)
+
+			createTeams(t, ctx, svc,
+				&entities.Team{TeamName: "root"},
+				&entities.Team{TeamName: "core", ParentTeam: "root", Settings: entities.TeamSettings{ReviewersCount: 1},
+					Members: []entities.TeamMember{
+						member("dt-author", entities.MemberRoleMember),
+						member("dt-reviewer", entities.MemberRoleMember),
+					}},
+				&entities.Team{TeamName: "child", ParentTeam: "core"},
+				&entities.Team{TeamName: "sibling", ParentTeam: "core"},
+				&entities.Team{TeamName: "other"},
+			)
+			if _, err := svc.prs.CreatePR(ctx, "dt-pr", "change", "dt-author", entities.PRSize{}); err != nil {
+				t.Fatalf("create PR: %v", err)
+			}
+
+			if err := svc.teams.DeleteTeam(ctx, "core", target); err != nil {
+				t.Fatalf("delete into %s: %v", target, err)
+			}
+			moved, err := svc.teams.GetTeam(ctx, target)
+			if err != nil {
+				t.Fatalf("get target: %v", err)
+			}
+			if len(moved.Members) != 2 {
+				t.Errorf("target has %d members, want the 2 moved ones", len(moved.Members))
+			}
+			for _, id := range []string{"dt-author", "dt-reviewer"} {
+				if u, err := svc.users.GetByID(ctx, id); err != nil || u.TeamName != target {
+					t.Errorf("%s after the delete = %+v, %v; want primary team %s", id, u, err, target)
+				}
+			}
+			if sibling, err := svc.teams.GetTeam(ctx, "sibling"); err != nil || sibling.ParentTeam != target {
+				t.Errorf("sibling after the delete = %+v, %v; want parent %s", sibling, err, target)
+			}
+			if target == "other" {
+				if child, err := svc.teams.GetTeam(ctx, "child"); err != nil || child.ParentTeam != "other" {
+					t.Errorf("child after the delete = %+v, %v; want parent other", child, err)
+				}
+			}
+			if target == "child" && moved.ParentTeam != "root" {
+				t.Errorf("child took the deleted team's place under %q, want root", moved.ParentTeam)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/f4ke-n0name/avito/internal/domain/entities"
 	"github.com/f4ke-n0name/avito/internal/domain/repositories"
@@ -23,15 +24,37 @@ func (r *TeamRepositoryPG) querier(ctx context.Context) dbQuerier {
 	return r.db.Pool
 }
 
+// teamColumns lists the team row without members, in the order the scans expect.
+const teamColumns = `team_name, COALESCE(parent_team, ''), reviewers_count, escalate_to_parent,
+        lead_review_min_lines, lead_review_min_files, mentorship_required, shadow_juniors,
+        selection_strategy, pairing_window_days, pairing_decay, max_open_reviews, overload_policy`
+
 func (r *TeamRepositoryPG) Create(ctx context.Context, t *entities.Team) error {
-	qTeam := `INSERT INTO teams (team_name) VALUES ($1)`
-	if _, err := r.querier(ctx).Exec(ctx, qTeam, t.TeamName); err != nil {
+	qTeam := `INSERT INTO teams (team_name, parent_team, reviewers_count, escalate_to_parent,
+                                 lead_review_min_lines, lead_review_min_files, mentorship_required, shadow_juniors,
+                                 selection_strategy, pairing_window_days, pairing_decay, max_open_reviews, overload_policy,
+                                 org_id)
+              VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	if _, err := r.querier(ctx).Exec(ctx, qTeam,
+		t.TeamName, t.ParentTeam, t.Settings.ReviewersCount, t.Settings.EscalateToParent,
+		t.Settings.LeadReviewMinLines, t.Settings.LeadReviewMinFiles,
+		t.Settings.MentorshipRequired, t.Settings.ShadowJuniors,
+		t.Settings.Strategy, t.Settings.PairingWindowDays, t.Settings.PairingDecay,
+		t.Settings.MaxOpenReviews, t.Settings.OverloadPolicy, repositories.OrgFromContext(ctx)); err != nil {
 		return err
 	}
-	qUser := `INSERT INTO users (user_id, username, is_active, team_name) VALUES ($1, $2, $3, $4)
-              ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active, team_name = EXCLUDED.team_name`
+	qLeave := `DELETE FROM team_memberships WHERE user_id = $1 AND is_primary AND org_id = $2`
+	qJoin := `INSERT INTO team_memberships (user_id, team_name, is_primary, review_weight, role, org_id)
+              VALUES ($1, $2, TRUE, $3, $4, $5)`
 	for _, member := range t.Members {
-		if _, err := r.querier(ctx).Exec(ctx, qUser, member.UserID, member.Username, member.IsActive, t.TeamName); err != nil {
+		if err := upsertUser(ctx, r.querier(ctx), &member.User); err != nil {
+			return err
+		}
+		if _, err := r.querier(ctx).Exec(ctx, qLeave, member.UserID, repositories.OrgFromContext(ctx)); err != nil {
+			return err
+		}
+		if _, err := r.querier(ctx).Exec(ctx, qJoin, member.UserID, t.TeamName, member.ReviewWeight, member.Role,
+			repositories.OrgFromContext(ctx)); err != nil {
 			return err
 		}
 	}
@@ -40,30 +63,321 @@ func (r *TeamRepositoryPG) Create(ctx context.Context, t *entities.Team) error {
 }
 
 func (r *TeamRepositoryPG) GetByName(ctx context.Context, name string) (*entities.Team, error) {
-	var tmp string
-	err := r.querier(ctx).QueryRow(ctx, `SELECT team_name FROM teams WHERE team_name=$1`, name).Scan(&tmp)
+	org := repositories.OrgFromContext(ctx)
+	team := &entities.Team{TeamName: name}
+	err := r.querier(ctx).QueryRow(ctx,
+		`SELECT `+teamColumns+` FROM teams WHERE team_name=$1 AND org_id=$2`, name, org).
+		Scan(&team.TeamName, &team.ParentTeam, &team.Settings.ReviewersCount, &team.Settings.EscalateToParent,
+			&team.Settings.LeadReviewMinLines, &team.Settings.LeadReviewMinFiles,
+			&team.Settings.MentorshipRequired, &team.Settings.ShadowJuniors,
+			&team.Settings.Strategy, &team.Settings.PairingWindowDays, &team.Settings.PairingDecay,
+			&team.Settings.MaxOpenReviews, &team.Settings.OverloadPolicy)
 	if err != nil {
 		if pgx.ErrNoRows == err {
 			return nil, nil
 		}
 		return nil, err
 	}
-	q := `SELECT user_id, username, is_active FROM users WHERE team_name=$1`
-	rows, err := r.querier(ctx).Query(ctx, q, name)
+	q := `
+        SELECT u.user_id, u.username, u.is_active, COALESCE(p.team_name, ''), u.seniority, u.max_open_reviews,
+               m.is_primary, m.review_weight, m.role
+        FROM team_memberships m
+        JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+        LEFT JOIN team_memberships p ON p.org_id = u.org_id AND p.user_id = u.user_id AND p.is_primary
+        WHERE m.team_name = $1 AND m.org_id = $2
+        ORDER BY u.user_id
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, name, org)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	team := &entities.Team{TeamName: name}
 	for rows.Next() {
-		var user entities.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive); err != nil {
+		var m entities.TeamMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.TeamName, &m.Seniority, &m.MaxOpenReviews,
+			&m.IsPrimary, &m.ReviewWeight, &m.Role); err != nil {
 			return nil, err
 		}
-		user.TeamName = name
-		team.Members = append(team.Members, user)
+		team.Members = append(team.Members, m)
 	}
 
 	return team, nil
 }
+
+func (r *TeamRepositoryPG) UpdateSettings(ctx context.Context, name string, settings entities.TeamSettings) error {
+	_, err := r.querier(ctx).Exec(ctx,
+		`UPDATE teams
+         SET reviewers_count = $2, escalate_to_parent = $3,
+             lead_review_min_lines = $4, lead_review_min_files = $5,
+             mentorship_required = $6, shadow_juniors = $7,
+             selection_strategy = $8, pairing_window_days = $9, pairing_decay = $10,
+             max_open_reviews = $11, overload_policy = $12
+         WHERE team_name = $1 AND org_id = $13`,
+		name, settings.ReviewersCount, settings.EscalateToParent,
+		settings.LeadReviewMinLines, settings.LeadReviewMinFiles,
+		settings.MentorshipRequired, settings.ShadowJuniors,
+		settings.Strategy, settings.PairingWindowDays, settings.PairingDecay,
+		settings.MaxOpenReviews, settings.OverloadPolicy, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// Rename changes the team key; memberships follow through ON UPDATE CASCADE.
+func (r *TeamRepositoryPG) Rename(ctx context.Context, oldName, newName string) error {
+	_, err := r.querier(ctx).Exec(ctx,
+		`UPDATE teams SET team_name = $2 WHERE team_name = $1 AND org_id = $3`,
+		oldName, newName, repositories.OrgFromContext(ctx))
+	return err
+}
+
+func (r *TeamRepositoryPG) Delete(ctx context.Context, name string) error {
+	_, err := r.querier(ctx).Exec(ctx, `DELETE FROM teams WHERE team_name = $1 AND org_id = $2`,
+		name, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// HasOpenPRs reports whether any member of the team authors or reviews an OPEN pull request.
+func (r *TeamRepositoryPG) HasOpenPRs(ctx context.Context, name string) (bool, error) {
+	q := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM team_memberships m
+            JOIN pull_requests pr ON pr.org_id = m.org_id AND pr.author_id = m.user_id
+            WHERE m.team_name = $1 AND m.org_id = $2 AND pr.status = 'OPEN'
+        ) OR EXISTS (
+            SELECT 1
+            FROM team_memberships m
+            JOIN pull_request_reviewers rr ON rr.org_id = m.org_id AND rr.reviewer_id = m.user_id
+            JOIN pull_requests pr ON pr.org_id = rr.org_id AND pr.pr_id = rr.pr_id
+            WHERE m.team_name = $1 AND m.org_id = $2 AND pr.status = 'OPEN'
+        )
+    `
+	var exists bool
+	err := r.querier(ctx).QueryRow(ctx, q, name, repositories.OrgFromContext(ctx)).Scan(&exists)
+	return exists, err
+}
+
+// MoveMembers transfers every membership of from to the team to, keeping the
+// primary flag, review weight and role, or drops the memberships when to is
+// empty. Users left without a primary team get one of their remaining teams
+// promoted.
+func (r *TeamRepositoryPG) MoveMembers(ctx context.Context, from, to string) error {
+	org := repositories.OrgFromContext(ctx)
+	if to != "" {
+		q := `
+            INSERT INTO team_memberships (user_id, team_name, is_primary, review_weight, role, org_id)
+            SELECT m.user_id, t.team_name, FALSE, m.review_weight, m.role, t.org_id
+            FROM team_memberships m
+            JOIN teams t ON t.team_name = $2 AND t.org_id = m.org_id
+            WHERE m.team_name = $1 AND m.org_id = $3
+            ON CONFLICT (org_id, user_id, team_name) DO NOTHING
+        `
+		if _, err := r.querier(ctx).Exec(ctx, q, from, to, org); err != nil {
+			return err
+		}
+	}
+
+	rows, err := r.querier(ctx).Query(ctx, `
+        DELETE FROM team_memberships
+        WHERE team_name = $1 AND org_id = $2
+        RETURNING user_id, is_primary
+    `, from, org)
+	if err != nil {
+		return err
+	}
+	var primaries []string
+	for rows.Next() {
+		var userID string
+		var isPrimary bool
+		if err := rows.Scan(&userID, &isPrimary); err != nil {
+			rows.Close()
+			return err
+		}
+		if isPrimary {
+			primaries = append(primaries, userID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if to != "" && len(primaries) > 0 {
+		if _, err := r.querier(ctx).Exec(ctx, `
+            UPDATE team_memberships SET is_primary = TRUE
+            WHERE team_name = $1 AND user_id = ANY($2) AND org_id = $3
+        `, to, primaries, org); err != nil {
+			return err
+		}
+	}
+	return promotePrimaries(ctx, r.querier(ctx))
+}
+
+func (r *TeamRepositoryPG) SetParent(ctx context.Context, name, parent string) error {
+	_, err := r.querier(ctx).Exec(ctx,
+		`UPDATE teams SET parent_team = NULLIF($2, '') WHERE team_name = $1 AND org_id = $3`,
+		name, parent, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// LockHierarchy locks the rows of name, parent and every ancestor of parent in
+// name order. Two reparentings that could close a cycle together share a row
+// of the chain, so the second one waits and then sees the first one's parent.
+func (r *TeamRepositoryPG) LockHierarchy(ctx context.Context, name, parent string) error {
+	q := `
+        WITH RECURSIVE chain(team_name, parent_team, depth) AS (
+            SELECT team_name, parent_team, 0 FROM teams WHERE team_name = $2 AND org_id = $3
+            UNION
+            SELECT t.team_name, t.parent_team, c.depth + 1
+            FROM teams t
+            JOIN chain c ON t.team_name = c.parent_team
+            WHERE t.org_id = $3 AND c.depth < 1000
+        )
+        SELECT team_name FROM teams
+        WHERE org_id = $3 AND (team_name = $1 OR team_name IN (SELECT team_name FROM chain))
+        ORDER BY team_name
+        FOR UPDATE
+    `
+	_, err := r.queryNames(ctx, q, name, parent, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// ReparentChildren moves the direct sub-teams of name under newParent, or makes
+// them roots when newParent is empty.
+func (r *TeamRepositoryPG) ReparentChildren(ctx context.Context, name, newParent string) error {
+	_, err := r.querier(ctx).Exec(ctx,
+		`UPDATE teams SET parent_team = NULLIF($2, '') WHERE parent_team = $1 AND org_id = $3`,
+		name, newParent, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// Ancestors returns the chain of parents of name, nearest first.
+func (r *TeamRepositoryPG) Ancestors(ctx context.Context, name string) ([]string, error) {
+	q := `
+        WITH RECURSIVE chain(team_name, parent_team, depth) AS (
+            SELECT team_name, parent_team, 0 FROM teams WHERE team_name = $1 AND org_id = $2
+            UNION
+            SELECT t.team_name, t.parent_team, c.depth + 1
+            FROM teams t
+            JOIN chain c ON t.team_name = c.parent_team
+            WHERE t.org_id = $2 AND c.depth < 1000
+        )
+        SELECT team_name FROM chain WHERE depth > 0 ORDER BY depth
+    `
+	return r.queryNames(ctx, q, name, repositories.OrgFromContext(ctx))
+}
+
+// Descendants returns every team below name in the hierarchy.
+func (r *TeamRepositoryPG) Descendants(ctx context.Context, name string) ([]string, error) {
+	q := `
+        WITH RECURSIVE tree(team_name, depth) AS (
+            SELECT team_name, 0 FROM teams WHERE team_name = $1 AND org_id = $2
+            UNION
+            SELECT t.team_name, d.depth + 1
+            FROM teams t
+            JOIN tree d ON t.parent_team = d.team_name
+            WHERE t.org_id = $2 AND d.depth < 1000
+        )
+        SELECT DISTINCT team_name FROM tree WHERE team_name <> $1 ORDER BY team_name
+    `
+	return r.queryNames(ctx, q, name, repositories.OrgFromContext(ctx))
+}
+
+func (r *TeamRepositoryPG) queryNames(ctx context.Context, q string, args ...any) ([]string, error) {
+	rows, err := r.querier(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+	return result, rows.Err()
+}
+
+// List returns every team with its parent and settings but without members.
+func (r *TeamRepositoryPG) List(ctx context.Context) ([]entities.Team, error) {
+	q := `SELECT ` + teamColumns + ` FROM teams WHERE org_id = $1 ORDER BY team_name`
+	rows, err := r.querier(ctx).Query(ctx, q, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.Team
+	for rows.Next() {
+		var t entities.Team
+		if err := rows.Scan(&t.TeamName, &t.ParentTeam, &t.Settings.ReviewersCount, &t.Settings.EscalateToParent,
+			&t.Settings.LeadReviewMinLines, &t.Settings.LeadReviewMinFiles,
+			&t.Settings.MentorshipRequired, &t.Settings.ShadowJuniors,
+			&t.Settings.Strategy, &t.Settings.PairingWindowDays, &t.Settings.PairingDecay,
+			&t.Settings.MaxOpenReviews, &t.Settings.OverloadPolicy); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// Stats counts members, authored pull requests and review assignments over the
+// union of the given teams. Users in several of them are counted once.
+func (r *TeamRepositoryPG) Stats(ctx context.Context, teams []string) (entities.TeamStatsCounters, error) {
+	q := `
+        WITH members AS (
+            SELECT DISTINCT m.user_id
+            FROM team_memberships m
+            WHERE m.team_name = ANY($1) AND m.org_id = $2
+        ),
+        prs AS (
+            SELECT status FROM pull_requests WHERE org_id = $2 AND author_id IN (SELECT user_id FROM members)
+        )
+        SELECT (SELECT COUNT(*) FROM members),
+               (SELECT COUNT(*) FROM members m JOIN users u ON u.org_id = $2 AND u.user_id = m.user_id WHERE u.is_active),
+               (SELECT COUNT(*) FROM prs WHERE status = 'OPEN'),
+               (SELECT COUNT(*) FROM prs WHERE status = 'MERGED'),
+               (SELECT COUNT(*) FROM prs WHERE status = 'CLOSED'),
+               (SELECT COUNT(*) FROM pull_request_reviewers
+                WHERE org_id = $2 AND reviewer_id IN (SELECT user_id FROM members)),
+               (SELECT COUNT(*) FROM pull_request_declines
+                WHERE org_id = $2 AND reviewer_id IN (SELECT user_id FROM members))
+    `
+	var c entities.TeamStatsCounters
+	err := r.querier(ctx).QueryRow(ctx, q, teams, repositories.OrgFromContext(ctx)).
+		Scan(&c.Members, &c.ActiveMembers, &c.OpenPRs, &c.MergedPRs, &c.ClosedPRs, &c.ReviewAssignments, &c.Declines)
+	return c, err
+}
+
+// Pairings counts review assignments since the given time on PRs authored by
+// the team's members, per author and reviewer.
+func (r *TeamRepositoryPG) Pairings(ctx context.Context, team string, since time.Time) ([]entities.ReviewPairing, error) {
+	q := `
+        SELECT pr.author_id, rr.reviewer_id, COUNT(*)
+        FROM pull_request_reviewers rr
+        JOIN pull_requests pr ON pr.org_id = rr.org_id AND pr.pr_id = rr.pr_id
+        WHERE pr.author_id IN (SELECT user_id FROM team_memberships WHERE team_name = $1 AND org_id = $3)
+          AND pr.org_id = $3 AND rr.assigned_at >= $2
+        GROUP BY pr.author_id, rr.reviewer_id
+        ORDER BY pr.author_id, rr.reviewer_id
+    `
+	rows, err := r.querier(ctx).Query(ctx, q, team, since, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.ReviewPairing
+	for rows.Next() {
+		var p entities.ReviewPairing
+		if err := rows.Scan(&p.AuthorID, &p.ReviewerID, &p.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
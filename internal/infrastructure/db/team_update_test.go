@@ -0,0 +1,153 @@
+package db_test
+
+import (
+	stderrors "errors"
+	"sync"
+	"testing"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+)
+
+// TestUpdateTeamKeepsUserRecord adds a user of another team with different
+// user fields. Only the membership may be written: the user keeps their name,
+// activity, seniority and primary team.
+func TestUpdateTeamKeepsUserRecord(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	senior := member("ku-user", entities.MemberRoleMember)
+	senior.Seniority = entities.SenioritySenior
+	for _, team := range []*entities.Team{
+		{TeamName: "home", Members: []entities.TeamMember{senior}},
+		{TeamName: "guest", Members: []entities.TeamMember{member("ku-other", entities.MemberRoleMember)}},
+	} {
+		if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+			t.Fatalf("create team %s: %v", team.TeamName, err)
+		}
+	}
+
+	changed := member("ku-user", entities.MemberRoleMember)
+	changed.Username = "renamed"
+	changed.IsActive = false
+	changed.Seniority = entities.SeniorityJunior
+	if _, err := svc.teams.UpdateTeam(ctx, "guest", entities.TeamUpdate{AddMembers: []entities.TeamMember{changed}}); err != nil {
+		t.Fatalf("update team: %v", err)
+	}
+
+	u, err := svc.users.GetByID(ctx, "ku-user")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if u.Username != "ku-user" || !u.IsActive || u.Seniority != entities.SenioritySenior || u.TeamName != "home" {
+		t.Errorf("user after update = %+v, want the original record in team home", u)
+	}
+	team, err := svc.teams.GetTeam(ctx, "guest")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if len(team.Members) != 2 {
+		t.Errorf("guest has %d members, want the added user as a second member", len(team.Members))
+	}
+}
+
+// TestUpdateTeamPrimaryConflict adds a member of another team as primary. The
+// update must fail with a conflict and keep the old primary team, unless the
+// caller asks to move the member.
+func TestUpdateTeamPrimaryConflict(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+	ctx := newOrg(t, pg)
+
+	for _, team := range []*entities.Team{
+		{TeamName: "home", Members: []entities.TeamMember{member("pc-user", entities.MemberRoleMember)}},
+		{TeamName: "away", Members: []entities.TeamMember{member("pc-other", entities.MemberRoleMember)}},
+	} {
+		if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+			t.Fatalf("create team %s: %v", team.TeamName, err)
+		}
+	}
+	primary := member("pc-user", entities.MemberRoleMember)
+	primary.IsPrimary = true
+	upd := entities.TeamUpdate{AddMembers: []entities.TeamMember{primary}}
+
+	_, err := svc.teams.UpdateTeam(ctx, "away", upd)
+	var conflict *errors.MemberConflictError
+	if !stderrors.As(err, &conflict) || len(conflict.Conflicts) != 1 || conflict.Conflicts[0].TeamName != "home" {
+		t.Fatalf("update without moving: err = %v, want a conflict with team home", err)
+	}
+	if u, err := svc.users.GetByID(ctx, "pc-user"); err != nil || u.TeamName != "home" {
+		t.Fatalf("user after the conflict = %+v, %v; want primary team home", u, err)
+	}
+	if team, err := svc.teams.GetTeam(ctx, "away"); err != nil || len(team.Members) != 1 {
+		t.Fatalf("away after the conflict = %+v, %v; want no new member", team, err)
+	}
+
+	upd.MoveExistingMembers = true
+	if _, err := svc.teams.UpdateTeam(ctx, "away", upd); err != nil {
+		t.Fatalf("update with moving: %v", err)
+	}
+	if u, err := svc.users.GetByID(ctx, "pc-user"); err != nil || u.TeamName != "away" {
+		t.Fatalf("user after the move = %+v, %v; want primary team away", u, err)
+	}
+}
+
+// TestConcurrentReparentingKeepsTreeAcyclic closes a cycle of four teams with
+// two parallel updates, each valid on its own. One of them must fail with
+// ErrTeamCycle.
+func TestConcurrentReparentingKeepsTreeAcyclic(t *testing.T) {
+	pg := testDB(t)
+	svc := newTestServices(pg)
+
+	for round := 0; round < 20; round++ {
+		ctx := newOrg(t, pg)
+		// a -> b -> c and d stand alone; a under d and d under c together
+		// make a -> b -> c -> d -> a.
+		for _, team := range []*entities.Team{
+			{TeamName: "c"},
+			{TeamName: "b", ParentTeam: "c"},
+			{TeamName: "a", ParentTeam: "b"},
+			{TeamName: "d"},
+		} {
+			if _, err := svc.teams.CreateTeam(ctx, team, false); err != nil {
+				t.Fatalf("create team %s: %v", team.TeamName, err)
+			}
+		}
+
+		moves := [][2]string{{"c", "d"}, {"d", "a"}}
+		errs := make([]error, len(moves))
+		var wg sync.WaitGroup
+		for i, move := range moves {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				parent := move[1]
+				_, errs[i] = svc.teams.UpdateTeam(ctx, move[0], entities.TeamUpdate{ParentTeam: &parent})
+			}()
+		}
+		wg.Wait()
+
+		failed := 0
+		for i, err := range errs {
+			switch {
+			case err == nil:
+			case stderrors.Is(err, errors.ErrTeamCycle):
+				failed++
+			default:
+				t.Fatalf("round %d: move %v: %v", round, moves[i], err)
+			}
+		}
+		if failed != 1 {
+			t.Fatalf("round %d: %d moves failed with a cycle, want exactly one", round, failed)
+		}
+		ancestors, err := db.NewTeamRepositoryPG(pg).Ancestors(ctx, "a")
+		if err != nil {
+			t.Fatalf("ancestors: %v", err)
+		}
+		if containsString(ancestors, "a") {
+			t.Fatalf("round %d: a is its own ancestor: %v", round, ancestors)
+		}
+	}
+}
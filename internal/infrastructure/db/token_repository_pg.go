@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+type TokenRepositoryPG struct {
+	db *PG
+}
+
+func NewTokenRepositoryPG(db *PG) repositories.TokenRepository {
+	return &TokenRepositoryPG{db: db}
+}
+
+func (r *TokenRepositoryPG) querier(ctx context.Context) dbQuerier {
+	if tx, ok := TxFromContext(ctx); ok && tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+const tokenColumns = `token_id, org_id, name, COALESCE(user_id, ''), role, teams, created_at, expires_at, last_used_at, revoked_at`
+
+func scanToken(row pgx.Row) (*entities.APIToken, error) {
+	t := &entities.APIToken{}
+	err := row.Scan(&t.TokenID, &t.OrgID, &t.Name, &t.UserID, &t.Role, &t.Teams, &t.CreatedAt,
+		&t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt)
+	return t, err
+}
+
+func (r *TokenRepositoryPG) Create(ctx context.Context, t *entities.APIToken, hash string) error {
+	q := `
+        INSERT INTO api_tokens (name, token_hash, user_id, role, teams, expires_at, org_id)
+        VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+        RETURNING token_id, created_at
+    `
+	teams := t.Teams
+	if teams == nil {
+		teams = []string{}
+	}
+	t.OrgID = repositories.OrgFromContext(ctx)
+	return r.querier(ctx).QueryRow(ctx, q, t.Name, hash, t.UserID, t.Role, teams, t.ExpiresAt, t.OrgID).
+		Scan(&t.TokenID, &t.CreatedAt)
+}
+
+// GetByHash is the one query not scoped by organization: it is how the
+// organization of a caller is found in the first place.
+func (r *TokenRepositoryPG) GetByHash(ctx context.Context, hash string) (*entities.APIToken, error) {
+	q := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+	t, err := scanToken(r.querier(ctx).QueryRow(ctx, q, hash))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+func (r *TokenRepositoryPG) List(ctx context.Context) ([]entities.APIToken, error) {
+	q := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE org_id = $1 ORDER BY token_id`
+	rows, err := r.querier(ctx).Query(ctx, q, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.APIToken
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *t)
+	}
+	return result, rows.Err()
+}
+
+func (r *TokenRepositoryPG) Revoke(ctx context.Context, id int64) (bool, error) {
+	tag, err := r.querier(ctx).Exec(ctx,
+		`UPDATE api_tokens SET revoked_at = now() WHERE token_id = $1 AND org_id = $2 AND revoked_at IS NULL`,
+		id, repositories.OrgFromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchLastUsed records a use of the token, at most once a minute.
+func (r *TokenRepositoryPG) TouchLastUsed(ctx context.Context, id int64) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+        UPDATE api_tokens SET last_used_at = now()
+        WHERE token_id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+    `, id)
+	return err
+}
@@ -23,24 +23,38 @@ func (r *UserRepositoryPG) querier(ctx context.Context) dbQuerier {
 	return r.db.Pool
 }
 
+// CreateOrUpdate upserts the user record itself; team membership is managed
+// separately through AddMembership and RemoveFromTeam. An empty seniority keeps
+// the stored one.
 func (r *UserRepositoryPG) CreateOrUpdate(ctx context.Context, u *entities.User) error {
-	q := `
-        INSERT INTO users (user_id, username, is_active, team_name)
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (user_id) DO UPDATE
+	return upsertUser(ctx, r.querier(ctx), u)
+}
+
+// upsertUser creates or updates a user of the context's organization. User ids
+// are unique within an organization, so another organization may use the same
+// id for a user of its own.
+func upsertUser(ctx context.Context, q dbQuerier, u *entities.User) error {
+	_, err := q.Exec(ctx, `
+        INSERT INTO users (user_id, username, is_active, seniority, org_id)
+        VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'middle'), $5)
+        ON CONFLICT (org_id, user_id) DO UPDATE
         SET username = EXCLUDED.username,
             is_active = EXCLUDED.is_active,
-            team_name = EXCLUDED.team_name
-    `
-	_, err := r.querier(ctx).Exec(ctx, q, u.UserID, u.Username, u.IsActive, u.TeamName)
+            seniority = CASE WHEN $4 = '' THEN users.seniority ELSE EXCLUDED.seniority END
+    `, u.UserID, u.Username, u.IsActive, u.Seniority, repositories.OrgFromContext(ctx))
 	return err
 }
 
 func (r *UserRepositoryPG) GetByID(ctx context.Context, id string) (*entities.User, error) {
-	q := `SELECT user_id, username, is_active, team_name FROM users WHERE user_id = $1`
+	q := `
+        SELECT u.user_id, u.username, u.is_active, COALESCE(p.team_name, ''), u.seniority, u.max_open_reviews
+        FROM users u
+        LEFT JOIN team_memberships p ON p.org_id = u.org_id AND p.user_id = u.user_id AND p.is_primary
+        WHERE u.user_id = $1 AND u.org_id = $2
+    `
 
 	u := &entities.User{}
-	err := r.querier(ctx).QueryRow(ctx, q, id).Scan(&u.UserID, &u.Username, &u.IsActive, &u.TeamName)
+	err := r.querier(ctx).QueryRow(ctx, q, id, repositories.OrgFromContext(ctx)).Scan(&u.UserID, &u.Username, &u.IsActive, &u.TeamName, &u.Seniority, &u.MaxOpenReviews)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
@@ -48,51 +62,142 @@ func (r *UserRepositoryPG) GetByID(ctx context.Context, id string) (*entities.Us
 }
 
 func (r *UserRepositoryPG) ListByTeam(ctx context.Context, team string) ([]entities.User, error) {
-	q := `
-        SELECT user_id, username, is_active, team_name
-        FROM users
-        WHERE team_name = $1
-    `
-
-	rows, err := r.querier(ctx).Query(ctx, q, team)
+	members, err := r.listMembers(ctx, team, false)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var result []entities.User
-	for rows.Next() {
-		var u entities.User
-		_ = rows.Scan(&u.UserID, &u.Username, &u.IsActive, &u.TeamName)
-		result = append(result, u)
+	result := make([]entities.User, 0, len(members))
+	for _, m := range members {
+		result = append(result, m.User)
 	}
 	return result, nil
 }
 
 func (r *UserRepositoryPG) ListActiveByTeam(ctx context.Context, team string) ([]entities.User, error) {
+	members, err := r.listMembers(ctx, team, true)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.User, 0, len(members))
+	for _, m := range members {
+		result = append(result, m.User)
+	}
+	return result, nil
+}
+
+// ListCandidates returns every member of the team, active or not, together
+// with the number of reviews each of them still has pending on open PRs.
+func (r *UserRepositoryPG) ListCandidates(ctx context.Context, team string) ([]entities.TeamMember, error) {
+	return r.listMembers(ctx, team, false)
+}
+
+func (r *UserRepositoryPG) listMembers(ctx context.Context, team string, onlyActive bool) ([]entities.TeamMember, error) {
 	q := `
-        SELECT user_id, username, is_active, team_name
-        FROM users
-        WHERE team_name = $1 AND is_active = true
+        SELECT u.user_id, u.username, u.is_active, COALESCE(p.team_name, ''), u.seniority, u.max_open_reviews,
+               m.is_primary, m.review_weight, m.role,
+               (SELECT COUNT(*)
+                FROM pull_request_reviewers rr
+                JOIN pull_requests pr ON pr.org_id = rr.org_id AND pr.pr_id = rr.pr_id
+                WHERE rr.org_id = u.org_id AND rr.reviewer_id = u.user_id
+                  AND rr.approved_at IS NULL AND pr.status = 'OPEN')
+        FROM team_memberships m
+        JOIN users u ON u.org_id = m.org_id AND u.user_id = m.user_id
+        LEFT JOIN team_memberships p ON p.org_id = u.org_id AND p.user_id = u.user_id AND p.is_primary
+        WHERE m.team_name = $1 AND m.org_id = $2 AND (u.is_active OR NOT $3)
+        ORDER BY u.user_id
     `
 
-	rows, err := r.querier(ctx).Query(ctx, q, team)
+	rows, err := r.querier(ctx).Query(ctx, q, team, repositories.OrgFromContext(ctx), onlyActive)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var res []entities.User
+	var result []entities.TeamMember
 	for rows.Next() {
-		var u entities.User
-		_ = rows.Scan(&u.UserID, &u.Username, &u.IsActive, &u.TeamName)
-		res = append(res, u)
+		var m entities.TeamMember
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.TeamName, &m.Seniority, &m.MaxOpenReviews,
+			&m.IsPrimary, &m.ReviewWeight, &m.Role, &m.OpenReviews); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
 	}
-	return res, nil
+	return result, rows.Err()
+}
+
+func (r *UserRepositoryPG) SetSeniority(ctx context.Context, id string, seniority entities.Seniority) error {
+	q := `UPDATE users SET seniority = $1 WHERE user_id = $2 AND org_id = $3`
+	_, err := r.querier(ctx).Exec(ctx, q, seniority, id, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// SetMaxOpenReviews sets the user's review limit; nil falls back to the team default.
+func (r *UserRepositoryPG) SetMaxOpenReviews(ctx context.Context, id string, limit *int) error {
+	q := `UPDATE users SET max_open_reviews = $1 WHERE user_id = $2 AND org_id = $3`
+	_, err := r.querier(ctx).Exec(ctx, q, limit, id, repositories.OrgFromContext(ctx))
+	return err
 }
 
 func (r *UserRepositoryPG) SetActive(ctx context.Context, id string, active bool) error {
-	q := `UPDATE users SET is_active = $1 WHERE user_id = $2`
-	_, err := r.querier(ctx).Exec(ctx, q, active, id)
+	q := `UPDATE users SET is_active = $1 WHERE user_id = $2 AND org_id = $3`
+	_, err := r.querier(ctx).Exec(ctx, q, active, id, repositories.OrgFromContext(ctx))
+	return err
+}
+
+// AddMembership adds the user to a team or updates an existing membership.
+// Making it primary demotes the user's previous primary membership.
+// Both the user and the team must belong to the context's organization.
+func (r *UserRepositoryPG) AddMembership(ctx context.Context, team string, m entities.TeamMember) error {
+	org := repositories.OrgFromContext(ctx)
+	if m.IsPrimary {
+		if _, err := r.querier(ctx).Exec(ctx, `
+            UPDATE team_memberships SET is_primary = FALSE
+            WHERE user_id = $1 AND is_primary AND team_name <> $2 AND org_id = $3
+        `, m.UserID, team, org); err != nil {
+			return err
+		}
+	}
+	q := `
+        INSERT INTO team_memberships (user_id, team_name, is_primary, review_weight, role, org_id)
+        SELECT u.user_id, t.team_name, $3, $4, $5, t.org_id
+        FROM users u, teams t
+        WHERE u.user_id = $1 AND u.org_id = $6 AND t.team_name = $2 AND t.org_id = $6
+        ON CONFLICT (org_id, user_id, team_name) DO UPDATE
+        SET is_primary = team_memberships.is_primary OR EXCLUDED.is_primary,
+            review_weight = EXCLUDED.review_weight,
+            role = EXCLUDED.role
+    `
+	_, err := r.querier(ctx).Exec(ctx, q, m.UserID, team, m.IsPrimary, m.ReviewWeight, m.Role, org)
+	return err
+}
+
+func (r *UserRepositoryPG) RemoveFromTeam(ctx context.Context, id, team string) error {
+	q := `
+        DELETE FROM team_memberships
+        WHERE user_id = $1 AND team_name = $2 AND org_id = $3
+    `
+	if _, err := r.querier(ctx).Exec(ctx, q, id, team, repositories.OrgFromContext(ctx)); err != nil {
+		return err
+	}
+	return promotePrimaries(ctx, r.querier(ctx))
+}
+
+// promotePrimaries makes one remaining membership primary for every user of the
+// context's organization who lost their primary team.
+func promotePrimaries(ctx context.Context, q dbQuerier) error {
+	_, err := q.Exec(ctx, `
+        UPDATE team_memberships m
+        SET is_primary = TRUE
+        FROM (
+            SELECT DISTINCT ON (t.user_id) t.user_id, t.team_name
+            FROM team_memberships t
+            WHERE t.org_id = $1 AND NOT EXISTS (
+                SELECT 1 FROM team_memberships p
+                WHERE p.org_id = t.org_id AND p.user_id = t.user_id AND p.is_primary
+            )
+            ORDER BY t.user_id, t.team_name
+        ) c
+        WHERE m.org_id = $1 AND m.user_id = c.user_id AND m.team_name = c.team_name
+    `, repositories.OrgFromContext(ctx))
 	return err
 }
@@ -0,0 +1,50 @@
+package events
+
+import "sync"
+
+// Broadcaster fans out commit notifications of the PR log to the streams of
+// the same organization. Each subscriber holds at most one pending wake-up,
+// so a slow reader coalesces notifications instead of blocking the writer;
+// it catches up by reading the log from its last event id.
+type Broadcaster struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+func (b *Broadcaster) Notify(orgID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[orgID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *Broadcaster) Subscribe(orgID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subs[orgID] == nil {
+		b.subs[orgID] = make(map[chan struct{}]struct{})
+	}
+	b.subs[orgID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs[orgID], ch)
+			if len(b.subs[orgID]) == 0 {
+				delete(b.subs, orgID)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/MicahParks/keyfunc/v3"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minRefreshGap limits how often an unknown key id may trigger a reload, so
+	// tokens with made-up ids cannot hammer the JWKS endpoint.
+	minRefreshGap = time.Minute
+	// unknownKeyWait is how long a request with an unknown key id waits for its
+	// turn to reload the set before it is rejected.
+	unknownKeyWait = time.Second
+	fetchTimeout   = 10 * time.Second
+)
+
+// signingUses are the "use" values of keys that may verify tokens; keys for
+// encryption are skipped.
+var signingUses = []jwkset.USE{jwkset.UseSig, ""}
+
+// NewKeySet loads a JWKS document from an http(s) URL or a file. A URL is
+// reloaded in the background every refresh interval and when a token names a
+// key id that has not been seen, which picks up rotated keys; the reloads end
+// with ctx. A file is read once.
+func NewKeySet(ctx context.Context, source string, refresh time.Duration) (keyfunc.Keyfunc, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		var doc jwkset.JWKSMarshal
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parsing JWKS: %w", err)
+		}
+		storage, err := doc.ToStorage()
+		if err != nil {
+			return nil, fmt.Errorf("parsing JWKS: %w", err)
+		}
+		return keyfunc.New(keyfunc.Options{Ctx: ctx, Storage: storage, UseWhitelist: signingUses})
+	}
+
+	remote, err := jwkset.NewStorageFromHTTP(source, jwkset.HTTPClientStorageOptions{
+		Ctx:                       ctx,
+		HTTPTimeout:               fetchTimeout,
+		NoErrorReturnFirstHTTPReq: true,
+		RefreshInterval:           refresh,
+		RefreshErrorHandler: func(_ context.Context, err error) {
+			log.Printf("failed to refresh JWKS from %s: %v", source, err)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	storage, err := jwkset.NewHTTPClient(jwkset.HTTPClientOptions{
+		HTTPURLs:          map[string]jwkset.Storage{source: remote},
+		RateLimitWaitMax:  unknownKeyWait,
+		RefreshUnknownKID: rate.NewLimiter(rate.Every(minRefreshGap), 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keyfunc.New(keyfunc.Options{Ctx: ctx, Storage: storage, UseWhitelist: signingUses})
+}
@@ -0,0 +1,134 @@
+package jwks
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+)
+
+// leeway tolerates clock skew between the issuer and this service.
+const leeway = time.Minute
+
+// signingMethods are the accepted token algorithms. The library only checks a
+// signature with a key of the algorithm's type, so a key can never be used
+// with an algorithm it was not issued for.
+var signingMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}
+
+// Config describes which tokens are accepted and how their claims map to a
+// principal. Issuer and Audience are required. Tokens without the
+// organization claim belong to DefaultOrg; when that is empty too, they are
+// rejected.
+type Config struct {
+	Issuer        string
+	Audience      string
+	OrgClaim      string
+	DefaultOrg    string
+	UserClaim     string
+	RolesClaim    string
+	TeamsClaim    string
+	AdminRole     string
+	TeamAdminRole string
+}
+
+// Verifier authenticates RS256 and ES256 signed JWTs against a key set.
+type Verifier struct {
+	keys   keyfunc.Keyfunc
+	parser *jwt.Parser
+	cfg    Config
+}
+
+func NewVerifier(keys keyfunc.Keyfunc, cfg Config) (interfaces.Authenticator, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, stderrors.New("JWT issuer and audience are required")
+	}
+	if cfg.OrgClaim == "" {
+		cfg.OrgClaim = "org_id"
+	}
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = "sub"
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.TeamsClaim == "" {
+		cfg.TeamsClaim = "teams"
+	}
+	if cfg.AdminRole == "" {
+		cfg.AdminRole = string(entities.RoleAdmin)
+	}
+	if cfg.TeamAdminRole == "" {
+		cfg.TeamAdminRole = string(entities.RoleTeamAdmin)
+	}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	)
+	return &Verifier{keys: keys, parser: parser, cfg: cfg}, nil
+}
+
+// Authenticate validates the token and maps its claims to a principal. Any
+// invalid token yields errors.ErrUnauthenticated.
+func (v *Verifier) Authenticate(ctx context.Context, token string) (*entities.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keys.KeyfuncCtx(ctx)); err != nil {
+		return nil, errors.ErrUnauthenticated
+	}
+	userID, _ := claims[v.cfg.UserClaim].(string)
+	if userID == "" {
+		return nil, errors.ErrUnauthenticated
+	}
+	orgID, _ := claims[v.cfg.OrgClaim].(string)
+	if orgID == "" {
+		orgID = v.cfg.DefaultOrg
+	}
+	if orgID == "" {
+		return nil, errors.ErrUnauthenticated
+	}
+
+	p := &entities.Principal{OrgID: orgID, UserID: userID, Role: entities.RoleMember}
+	roles := stringList(claims[v.cfg.RolesClaim])
+	switch {
+	case contains(roles, v.cfg.AdminRole):
+		p.Role = entities.RoleAdmin
+	case contains(roles, v.cfg.TeamAdminRole):
+		p.Role = entities.RoleTeamAdmin
+		p.Teams = stringList(claims[v.cfg.TeamsClaim])
+	}
+	return p, nil
+}
+
+// stringList reads a claim that is either a single string or a list of them.
+func stringList(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var out []string
+		for _, x := range t {
+			if s, ok := x.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
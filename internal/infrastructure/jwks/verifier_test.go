@@ -0,0 +1,178 @@
+package jwks_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/errors"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/jwks"
+)
+
+const (
+	issuer   = "https://sso.example.com"
+	audience = "reviewer-service"
+)
+
+// signingKeys are the private keys behind the test JWKS.
+type signingKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+// newVerifier writes the public keys of a fresh RSA and P-256 key pair to a
+// JWKS file and returns a verifier reading it.
+func newVerifier(t *testing.T) (interfaces.Authenticator, signingKeys) {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	store := jwkset.NewMemoryStorage()
+	for kid, key := range map[string]any{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey} {
+		jwk, err := jwkset.NewJWKFromKey(key, jwkset.JWKOptions{
+			Metadata: jwkset.JWKMetadataOptions{KID: kid, USE: jwkset.UseSig},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.KeyWrite(ctx, jwk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	doc, err := store.JSONPublic(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, doc, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := jwks.NewKeySet(ctx, path, time.Hour)
+	if err != nil {
+		t.Fatalf("load key set: %v", err)
+	}
+	v, err := jwks.NewVerifier(keys, jwks.Config{Issuer: issuer, Audience: audience})
+	if err != nil {
+		t.Fatalf("new verifier: %v", err)
+	}
+	return v, signingKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    issuer,
+		"aud":    audience,
+		"sub":    "u1",
+		"org_id": "acme",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"roles":  []string{"team-admin"},
+		"teams":  []string{"backend"},
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestVerifierAcceptsValidTokens(t *testing.T) {
+	v, keys := newVerifier(t)
+	for name, token := range map[string]string{
+		"RS256": sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, validClaims()),
+		"ES256": sign(t, jwt.SigningMethodES256, "ec", keys.ec, validClaims()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := v.Authenticate(context.Background(), token)
+			if err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+			want := entities.Principal{OrgID: "acme", UserID: "u1", Role: entities.RoleTeamAdmin, Teams: []string{"backend"}}
+			if p.OrgID != want.OrgID || p.UserID != want.UserID || p.Role != want.Role ||
+				len(p.Teams) != 1 || p.Teams[0] != "backend" {
+				t.Errorf("principal = %+v, want %+v", *p, want)
+			}
+		})
+	}
+}
+
+func TestVerifierRejectsInvalidTokens(t *testing.T) {
+	v, keys := newVerifier(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	with := func(key string, value any) jwt.MapClaims {
+		c := validClaims()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"bad signature", sign(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims())},
+		{"expired", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("exp", time.Now().Add(-time.Hour).Unix()))},
+		{"no expiry", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("exp", nil))},
+		{"wrong audience", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("aud", "other-service"))},
+		{"no audience", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("aud", nil))},
+		{"wrong issuer", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("iss", "https://evil.example.com"))},
+		{"no issuer", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("iss", nil))},
+		{"unknown kid", sign(t, jwt.SigningMethodRS256, "missing", keys.rsa, validClaims())},
+		{"key of another type", sign(t, jwt.SigningMethodES256, "rsa", keys.ec, validClaims())},
+		{"alg none", unsigned},
+		{"HS256 with public key", sign(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), validClaims())},
+		{"no subject", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, with("sub", nil))},
+		{"malformed", "not.a.token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Authenticate(context.Background(), tt.token)
+			if !stderrors.Is(err, errors.ErrUnauthenticated) {
+				t.Errorf("authenticate = %+v, %v; want ErrUnauthenticated", p, err)
+			}
+		})
+	}
+}
+
+func TestVerifierRequiresIssuerAndAudience(t *testing.T) {
+	for _, cfg := range []jwks.Config{{Issuer: issuer}, {Audience: audience}} {
+		if _, err := jwks.NewVerifier(nil, cfg); err == nil {
+			t.Errorf("NewVerifier(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
@@ -3,12 +3,24 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/f4ke-n0name/avito/api"
+	grpcServer "github.com/f4ke-n0name/avito/internal/app/grpc"
 	httpServer "github.com/f4ke-n0name/avito/internal/app/http"
+	"github.com/f4ke-n0name/avito/internal/domain/entities"
+	"github.com/f4ke-n0name/avito/internal/domain/repositories"
 	"github.com/f4ke-n0name/avito/internal/domain/services"
+	"github.com/f4ke-n0name/avito/internal/domain/services/interfaces"
 	"github.com/f4ke-n0name/avito/internal/infrastructure/db"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/events"
+	"github.com/f4ke-n0name/avito/internal/infrastructure/jwks"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -24,16 +36,151 @@ func main() {
 	userRepo := db.NewUserRepositoryPG(database)
 	teamRepo := db.NewTeamRepositoryPG(database)
 	prRepo := db.NewPRRepositoryPG(database)
+	eventRepo := db.NewPREventRepositoryPG(database)
+	decisionRepo := db.NewAssignmentDecisionRepositoryPG(database)
+	tokenRepo := db.NewTokenRepositoryPG(database)
+	orgRepo := db.NewOrganizationRepositoryPG(database)
+	idempotencyRepo := db.NewIdempotencyRepositoryPG(database)
 
 	withTx := func(ctx context.Context, fn func(ctx context.Context) error) error {
 		return database.WithTx(ctx, fn)
 	}
+	readOnlyTx := func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return database.WithReadOnlyTx(ctx, fn)
+	}
 
 	userSvc := services.NewUserService(userRepo)
-	teamSvc := services.NewTeamService(teamRepo, userRepo)
-	prSvc := services.NewPRService(userRepo, teamRepo, prRepo, withTx)
+	teamSvc := services.NewTeamService(teamRepo, userRepo, withTx)
+	// DETERMINISTIC_SELECTION=true seeds every reviewer selection from the PR id
+	// so that assignments can be reproduced.
+	random := services.NewRandomSource(time.Now().UnixNano())
+	if os.Getenv("DETERMINISTIC_SELECTION") == "true" {
+		random = services.NewDeterministicSource()
+	}
+
+	// broadcaster wakes the event streams of this instance after PR writes.
+	broadcaster := events.NewBroadcaster()
+	prSvc := services.NewPRService(userRepo, teamRepo, prRepo, eventRepo, decisionRepo, random, broadcaster, withTx, readOnlyTx)
+
+	authSvc := services.NewAuthService(tokenRepo, userRepo)
+
+	// IDEMPOTENCY_TTL is how long responses to requests with an Idempotency-Key
+	// are kept for replay.
+	idempotencyTTL := 24 * time.Hour
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		if idempotencyTTL, err = time.ParseDuration(v); err != nil || idempotencyTTL <= 0 {
+			log.Fatalf("invalid IDEMPOTENCY_TTL: %q", v)
+		}
+	}
+	// IDEMPOTENCY_LEASE is how long a request may hold its key before a retry
+	// can claim it again, e.g. after the process handling it crashed.
+	idempotencyLease := time.Minute
+	if v := os.Getenv("IDEMPOTENCY_LEASE"); v != "" {
+		if idempotencyLease, err = time.ParseDuration(v); err != nil || idempotencyLease <= 0 {
+			log.Fatalf("invalid IDEMPOTENCY_LEASE: %q", v)
+		}
+	}
+	idempotencySvc := services.NewIdempotencyService(idempotencyRepo, idempotencyTTL, idempotencyLease)
+
+	// ORGANIZATIONS lists the tenants to provision besides BOOTSTRAP_ORG, which
+	// defaults to the organization existing data was migrated into.
+	bootstrapOrg := os.Getenv("BOOTSTRAP_ORG")
+	if bootstrapOrg == "" {
+		bootstrapOrg = entities.DefaultOrgID
+	}
+	orgs := []string{bootstrapOrg}
+	if v := os.Getenv("ORGANIZATIONS"); v != "" {
+		orgs = append(orgs, strings.Split(v, ",")...)
+	}
+	for _, id := range orgs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if err := orgRepo.Ensure(context.Background(), &entities.Organization{OrgID: id, Name: id}); err != nil {
+			log.Fatalf("failed to provision organization %q: %v", id, err)
+		}
+	}
+
+	// BOOTSTRAP_ADMIN_TOKEN registers an admin token of BOOTSTRAP_ORG so that
+	// the first real tokens can be issued through the API.
+	if secret := os.Getenv("BOOTSTRAP_ADMIN_TOKEN"); secret != "" {
+		bootstrap := &entities.APIToken{Name: "bootstrap", Role: entities.RoleAdmin}
+		ctx := repositories.WithOrg(context.Background(), bootstrapOrg)
+		if err := authSvc.EnsureToken(ctx, bootstrap, secret); err != nil {
+			log.Fatalf("failed to register bootstrap token: %v", err)
+		}
+	}
+
+	// AUTH_MODE=jwt accepts tokens of the company SSO instead of API tokens.
+	var authn interfaces.Authenticator = authSvc
+	if os.Getenv("AUTH_MODE") == "jwt" {
+		source := os.Getenv("JWKS_URL")
+		if source == "" {
+			source = os.Getenv("JWKS_FILE")
+		}
+		if source == "" {
+			log.Fatal("AUTH_MODE=jwt requires JWKS_URL or JWKS_FILE")
+		}
+		refresh := time.Hour
+		if v := os.Getenv("JWKS_REFRESH"); v != "" {
+			if refresh, err = time.ParseDuration(v); err != nil {
+				log.Fatalf("invalid JWKS_REFRESH: %v", err)
+			}
+		}
+		keys, err := jwks.NewKeySet(context.Background(), source, refresh)
+		if err != nil {
+			log.Fatalf("failed to load JWKS: %v", err)
+		}
+		authn, err = jwks.NewVerifier(keys, jwks.Config{
+			Issuer:        os.Getenv("JWT_ISSUER"),
+			Audience:      os.Getenv("JWT_AUDIENCE"),
+			OrgClaim:      os.Getenv("JWT_ORG_CLAIM"),
+			DefaultOrg:    os.Getenv("JWT_DEFAULT_ORG"),
+			UserClaim:     os.Getenv("JWT_USER_CLAIM"),
+			RolesClaim:    os.Getenv("JWT_ROLES_CLAIM"),
+			TeamsClaim:    os.Getenv("JWT_TEAMS_CLAIM"),
+			AdminRole:     os.Getenv("JWT_ADMIN_ROLE"),
+			TeamAdminRole: os.Getenv("JWT_TEAM_ADMIN_ROLE"),
+		})
+		if err != nil {
+			log.Fatalf("invalid JWT configuration: %v", err)
+		}
+	}
+
+	doc, err := api.Load()
+	if err != nil {
+		log.Fatalf("invalid openapi document: %v", err)
+	}
+	spec, err := httpServer.NewOpenAPI(doc)
+	if err != nil {
+		log.Fatalf("failed to load openapi document: %v", err)
+	}
+
+	// GRPC_PORT serves the same API over gRPC; GRPC_REFLECTION=true lets tools
+	// such as grpcurl discover the services.
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("failed to listen on grpc port: %v", err)
+	}
+	rpc := grpcServer.NewServer(prSvc, userSvc, teamSvc, authn)
+	g := grpc.NewServer(rpc.ServerOptions()...)
+	rpc.Register(g)
+	if os.Getenv("GRPC_REFLECTION") == "true" {
+		reflection.Register(g)
+	}
+	go func() {
+		log.Printf("gRPC server started at :%s", grpcPort)
+		if err := g.Serve(lis); err != nil {
+			log.Fatalf("grpc server stopped: %v", err)
+		}
+	}()
 
-	server := httpServer.NewServer(prSvc, userSvc, teamSvc)
+	server := httpServer.NewServer(prSvc, userSvc, teamSvc, authSvc, authn, spec, broadcaster, idempotencySvc)
 	r := gin.Default()
 	server.RegisterRoutes(r)
 
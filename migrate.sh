@@ -7,6 +7,7 @@ until pg_isready -h db -p 5432; do
 done
 
 echo "Applying migrations..."
-migrate -path ./migrations -database "$DATABASE_URL" up || true
+# Migrations change the schema, which the service role may not do.
+migrate -path ./migrations -database "${MIGRATE_DATABASE_URL:-$DATABASE_URL}" up || true
 
 echo "Migrations applied"
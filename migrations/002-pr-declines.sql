@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE pull_request_declines (
+    pr_id TEXT NOT NULL,
+    reviewer_id TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    declined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (pr_id, reviewer_id),
+    CONSTRAINT fk_prdecl_pr FOREIGN KEY (pr_id)
+        REFERENCES pull_requests (pr_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_prdecl_user FOREIGN KEY (reviewer_id)
+        REFERENCES users (user_id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_prdecl_reviewer_id ON pull_request_declines(reviewer_id);
+
+COMMIT;
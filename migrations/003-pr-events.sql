@@ -0,0 +1,47 @@
+BEGIN;
+
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_status_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE pull_request_reviewers ADD COLUMN approved_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE pr_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN (
+        'created', 'reviewer_assigned', 'reviewer_replaced', 'reviewed', 'merged', 'closed'
+    )),
+    old_reviewer_id TEXT,
+    new_reviewer_id TEXT,
+    actor_id TEXT,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT fk_prevents_pr FOREIGN KEY (pr_id)
+        REFERENCES pull_requests (pr_id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_prevents_pr_id ON pr_events(pr_id, event_id);
+
+CREATE FUNCTION pr_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pr_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pr_events_append_only
+    BEFORE UPDATE OR DELETE ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION pr_events_append_only();
+
+-- Backfill what can still be recovered for pull requests created before the log existed.
+INSERT INTO pr_events (pr_id, event_type, actor_id, created_at)
+SELECT pr_id, 'created', author_id, created_at FROM pull_requests;
+
+INSERT INTO pr_events (pr_id, event_type, new_reviewer_id, created_at)
+SELECT pr_id, 'reviewer_assigned', reviewer_id, assigned_at FROM pull_request_reviewers;
+
+INSERT INTO pr_events (pr_id, event_type, created_at)
+SELECT pr_id, 'merged', merged_at FROM pull_requests WHERE status = 'MERGED';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX idx_pr_author_id;
+DROP INDEX idx_pr_status;
+
+CREATE INDEX idx_pr_created_at_pr_id ON pull_requests(created_at DESC, pr_id DESC);
+CREATE INDEX idx_pr_author_created ON pull_requests(author_id, created_at DESC, pr_id DESC);
+CREATE INDEX idx_pr_status_created ON pull_requests(status, created_at DESC, pr_id DESC);
+CREATE INDEX idx_pr_merged_at ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
+
+CREATE INDEX idx_prrev_reviewer_pr ON pull_request_reviewers(reviewer_id, pr_id);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- idx_prrev_reviewer_pr covers every lookup the single-column index served.
+DROP INDEX idx_prrev_reviewer_id;
+
+CREATE INDEX idx_prrev_reviewer_pending ON pull_request_reviewers(reviewer_id, pr_id)
+    WHERE approved_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE teams ADD COLUMN reviewers_count INT NOT NULL DEFAULT 2
+    CHECK (reviewers_count > 0);
+
+-- Members removed from a team, or left behind by a deleted team, have no team.
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT fk_users_team;
+ALTER TABLE users ADD CONSTRAINT fk_users_team FOREIGN KEY (team_name)
+    REFERENCES teams (team_name)
+    ON UPDATE CASCADE
+    ON DELETE RESTRICT;
+
+COMMIT;
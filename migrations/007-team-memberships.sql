@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE team_memberships (
+    user_id TEXT NOT NULL,
+    team_name TEXT NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    review_weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (review_weight >= 0),
+    PRIMARY KEY (user_id, team_name),
+    CONSTRAINT fk_memberships_user FOREIGN KEY (user_id)
+        REFERENCES users (user_id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_memberships_team FOREIGN KEY (team_name)
+        REFERENCES teams (team_name)
+        ON UPDATE CASCADE
+        ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX ux_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+CREATE INDEX idx_memberships_team_name ON team_memberships(team_name);
+
+INSERT INTO team_memberships (user_id, team_name, is_primary)
+SELECT user_id, team_name, TRUE FROM users WHERE team_name IS NOT NULL;
+
+ALTER TABLE users DROP COLUMN team_name;
+
+COMMIT;
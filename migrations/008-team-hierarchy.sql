@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE teams ADD COLUMN parent_team TEXT;
+ALTER TABLE teams ADD COLUMN escalate_to_parent BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE teams ADD CONSTRAINT fk_teams_parent FOREIGN KEY (parent_team)
+    REFERENCES teams (team_name)
+    ON UPDATE CASCADE
+    ON DELETE RESTRICT;
+
+ALTER TABLE teams ADD CONSTRAINT chk_teams_parent_not_self CHECK (parent_team <> team_name);
+
+CREATE INDEX idx_teams_parent_team ON teams(parent_team);
+
+COMMIT;
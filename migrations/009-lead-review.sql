@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE team_memberships ADD COLUMN role TEXT NOT NULL DEFAULT 'member'
+    CHECK (role IN ('member', 'lead'));
+
+ALTER TABLE teams ADD COLUMN lead_review_min_lines INT NOT NULL DEFAULT 0 CHECK (lead_review_min_lines >= 0);
+ALTER TABLE teams ADD COLUMN lead_review_min_files INT NOT NULL DEFAULT 0 CHECK (lead_review_min_files >= 0);
+
+ALTER TABLE pull_requests ADD COLUMN lines_changed INT NOT NULL DEFAULT 0 CHECK (lines_changed >= 0);
+ALTER TABLE pull_requests ADD COLUMN files_changed INT NOT NULL DEFAULT 0 CHECK (files_changed >= 0);
+
+ALTER TABLE pull_request_reviewers ADD COLUMN slot TEXT NOT NULL DEFAULT 'regular'
+    CHECK (slot IN ('regular', 'lead'));
+
+COMMIT;
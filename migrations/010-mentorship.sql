@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN seniority TEXT NOT NULL DEFAULT 'middle'
+    CHECK (seniority IN ('junior', 'middle', 'senior'));
+
+ALTER TABLE teams ADD COLUMN mentorship_required BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE teams ADD COLUMN shadow_juniors BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_slot_check;
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_slot_check
+    CHECK (slot IN ('regular', 'lead', 'shadow'));
+
+COMMIT;
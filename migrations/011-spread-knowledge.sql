@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE teams ADD COLUMN selection_strategy TEXT NOT NULL DEFAULT 'random'
+    CHECK (selection_strategy IN ('random', 'spread_knowledge'));
+ALTER TABLE teams ADD COLUMN pairing_window_days INT NOT NULL DEFAULT 30
+    CHECK (pairing_window_days > 0);
+ALTER TABLE teams ADD COLUMN pairing_decay DOUBLE PRECISION NOT NULL DEFAULT 0.5
+    CHECK (pairing_decay > 0 AND pairing_decay <= 1);
+
+CREATE INDEX idx_prrev_assigned_at ON pull_request_reviewers (assigned_at);
+
+COMMIT;
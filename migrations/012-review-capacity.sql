@@ -0,0 +1,16 @@
+BEGIN;
+
+-- NULL falls back to the team default.
+ALTER TABLE users ADD COLUMN max_open_reviews INT CHECK (max_open_reviews > 0);
+
+-- Zero means unlimited.
+ALTER TABLE teams ADD COLUMN max_open_reviews INT NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+ALTER TABLE teams ADD COLUMN overload_policy TEXT NOT NULL DEFAULT 'assign'
+    CHECK (overload_policy IN ('assign', 'queue', 'fail'));
+
+ALTER TABLE pull_requests ADD COLUMN overloaded BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE pull_requests ADD COLUMN queued BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_pr_queued ON pull_requests (created_at, pr_id) WHERE queued;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE assignment_decisions (
+    decision_id BIGSERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL,
+    reviewer_id TEXT NOT NULL,
+    slot TEXT NOT NULL CHECK (slot IN ('regular', 'lead', 'shadow')),
+    strategy TEXT NOT NULL,
+    replaced_reviewer_id TEXT,
+    over_capacity BOOLEAN NOT NULL DEFAULT FALSE,
+    candidates JSONB NOT NULL,
+    excluded JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT fk_decisions_pr FOREIGN KEY (pr_id)
+        REFERENCES pull_requests (pr_id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_decisions_pr_id ON assignment_decisions(pr_id, decision_id);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE api_tokens (
+    token_id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    -- NULL for service tokens that do not act as a particular user.
+    user_id TEXT,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'team-admin', 'member')),
+    teams TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_tokens_user FOREIGN KEY (user_id)
+        REFERENCES users (user_id)
+        ON DELETE CASCADE
+);
+
+COMMIT;
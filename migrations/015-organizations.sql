@@ -0,0 +1,87 @@
+BEGIN;
+
+CREATE TABLE organizations (
+    org_id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Existing data belongs to the default organization.
+INSERT INTO organizations (org_id, name) VALUES ('default', 'Default');
+
+ALTER TABLE teams ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default'
+    REFERENCES organizations (org_id);
+ALTER TABLE users ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default'
+    REFERENCES organizations (org_id);
+ALTER TABLE pull_requests ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default'
+    REFERENCES organizations (org_id);
+ALTER TABLE api_tokens ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default'
+    REFERENCES organizations (org_id);
+
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE api_tokens ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX idx_teams_org_id ON teams(org_id, team_name);
+CREATE INDEX idx_users_org_id ON users(org_id, user_id);
+CREATE INDEX idx_pr_org_created ON pull_requests(org_id, created_at DESC, pr_id DESC);
+CREATE INDEX idx_tokens_org_id ON api_tokens(org_id, token_id);
+
+-- Row-level security backs up the org_id filters of the application queries.
+-- The service sets app.org_id on every connection it takes from the pool; rows
+-- of other organizations are then neither visible nor writable. Tables without
+-- org_id follow the row they belong to. Policies do not apply to superusers,
+-- so the service must connect as a regular role.
+ALTER TABLE teams ENABLE ROW LEVEL SECURITY;
+ALTER TABLE teams FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON teams
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON users
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+ALTER TABLE pull_requests ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pull_requests FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON pull_requests
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+ALTER TABLE team_memberships ENABLE ROW LEVEL SECURITY;
+ALTER TABLE team_memberships FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON team_memberships
+    USING (EXISTS (SELECT 1 FROM teams t WHERE t.team_name = team_memberships.team_name))
+    WITH CHECK (EXISTS (SELECT 1 FROM teams t WHERE t.team_name = team_memberships.team_name)
+                AND EXISTS (SELECT 1 FROM users u WHERE u.user_id = team_memberships.user_id));
+
+ALTER TABLE pull_request_reviewers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pull_request_reviewers FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON pull_request_reviewers
+    USING (EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = pull_request_reviewers.pr_id))
+    WITH CHECK (EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = pull_request_reviewers.pr_id)
+                AND EXISTS (SELECT 1 FROM users u WHERE u.user_id = pull_request_reviewers.reviewer_id));
+
+ALTER TABLE pull_request_declines ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pull_request_declines FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON pull_request_declines
+    USING (EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = pull_request_declines.pr_id));
+
+ALTER TABLE pr_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pr_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON pr_events
+    USING (EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = pr_events.pr_id));
+
+ALTER TABLE assignment_decisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE assignment_decisions FORCE ROW LEVEL SECURITY;
+CREATE POLICY org_isolation ON assignment_decisions
+    USING (EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = assignment_decisions.pr_id));
+
+-- api_tokens stays without a policy: a token is looked up by its hash before
+-- the caller's organization is known. Queries other than the lookup filter by
+-- org_id.
+
+COMMIT;
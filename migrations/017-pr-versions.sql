@@ -0,0 +1,42 @@
+BEGIN;
+
+-- version counts the changes of a pull request, including changes of its
+-- reviewers, approvals and declines. Clients send it back in If-Match.
+ALTER TABLE pull_requests ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+CREATE FUNCTION pull_requests_bump_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW.version = OLD.version THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pull_requests_version
+    BEFORE UPDATE ON pull_requests
+    FOR EACH ROW EXECUTE FUNCTION pull_requests_bump_version();
+
+CREATE FUNCTION pr_children_bump_version() RETURNS trigger AS $$
+DECLARE
+    changed_pr TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_pr := OLD.pr_id;
+    ELSE
+        changed_pr := NEW.pr_id;
+    END IF;
+    UPDATE pull_requests SET version = version + 1 WHERE pr_id = changed_pr;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pr_reviewers_version
+    AFTER INSERT OR UPDATE OR DELETE ON pull_request_reviewers
+    FOR EACH ROW EXECUTE FUNCTION pr_children_bump_version();
+
+CREATE TRIGGER trg_pr_declines_version
+    AFTER INSERT OR UPDATE OR DELETE ON pull_request_declines
+    FOR EACH ROW EXECUTE FUNCTION pr_children_bump_version();
+
+COMMIT;
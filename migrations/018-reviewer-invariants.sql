@@ -0,0 +1,39 @@
+BEGIN;
+
+-- Backstops for the reviewer rules the service enforces under the PR row
+-- lock: the reviewers of a merged or closed PR never change, and the author
+-- never reviews their own PR. Approvals of existing reviewers stay allowed.
+CREATE FUNCTION pr_reviewers_check() RETURNS trigger AS $$
+DECLARE
+    pr_status TEXT;
+    pr_author TEXT;
+    changed_pr TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_pr := OLD.pr_id;
+    ELSE
+        changed_pr := NEW.pr_id;
+    END IF;
+
+    SELECT status, author_id INTO pr_status, pr_author
+    FROM pull_requests WHERE pr_id = changed_pr;
+
+    IF pr_status <> 'OPEN' THEN
+        RAISE EXCEPTION 'reviewers of % pull request % cannot change', lower(pr_status), changed_pr;
+    END IF;
+    IF TG_OP = 'INSERT' AND NEW.reviewer_id = pr_author THEN
+        RAISE EXCEPTION 'author % cannot review pull request %', pr_author, changed_pr;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pr_reviewers_check
+    BEFORE INSERT OR DELETE ON pull_request_reviewers
+    FOR EACH ROW EXECUTE FUNCTION pr_reviewers_check();
+
+COMMIT;
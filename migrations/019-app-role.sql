@@ -0,0 +1,25 @@
+BEGIN;
+
+-- The service connects as a member of avito_app. The role is neither a
+-- superuser nor allowed to bypass row-level security, so the org_isolation
+-- policies apply to every query it runs. Login roles are created per
+-- deployment (see docker/initdb) and granted avito_app.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'avito_app') THEN
+        CREATE ROLE avito_app NOLOGIN NOSUPERUSER NOBYPASSRLS;
+    END IF;
+END
+$$;
+
+GRANT USAGE ON SCHEMA public TO avito_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO avito_app;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO avito_app;
+
+-- Tables and sequences of later migrations are granted as well.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO avito_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES TO avito_app;
+
+COMMIT;
@@ -0,0 +1,37 @@
+BEGIN;
+
+-- Team names are unique within an organization, not across all of them. The
+-- memberships carry the organization so that their foreign key can point at
+-- the composite key.
+ALTER TABLE team_memberships ADD COLUMN org_id TEXT REFERENCES organizations (org_id);
+UPDATE team_memberships m SET org_id = t.org_id FROM teams t WHERE t.team_name = m.team_name;
+ALTER TABLE team_memberships ALTER COLUMN org_id SET NOT NULL;
+
+ALTER TABLE team_memberships DROP CONSTRAINT fk_memberships_team;
+ALTER TABLE teams DROP CONSTRAINT fk_teams_parent;
+ALTER TABLE teams DROP CONSTRAINT teams_pkey;
+ALTER TABLE teams ADD PRIMARY KEY (org_id, team_name);
+DROP INDEX idx_teams_org_id;
+
+ALTER TABLE team_memberships ADD CONSTRAINT fk_memberships_team FOREIGN KEY (org_id, team_name)
+    REFERENCES teams (org_id, team_name)
+    ON UPDATE CASCADE
+    ON DELETE RESTRICT;
+DROP INDEX idx_memberships_team_name;
+CREATE INDEX idx_memberships_org_team ON team_memberships(org_id, team_name);
+
+ALTER TABLE teams ADD CONSTRAINT fk_teams_parent FOREIGN KEY (org_id, parent_team)
+    REFERENCES teams (org_id, team_name)
+    ON UPDATE CASCADE
+    ON DELETE RESTRICT;
+DROP INDEX idx_teams_parent_team;
+CREATE INDEX idx_teams_org_parent ON teams(org_id, parent_team);
+
+-- A membership belongs to the organization of its team and of its user.
+DROP POLICY org_isolation ON team_memberships;
+CREATE POLICY org_isolation ON team_memberships
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true)
+                AND EXISTS (SELECT 1 FROM users u WHERE u.user_id = team_memberships.user_id));
+
+COMMIT;
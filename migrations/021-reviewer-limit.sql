@@ -0,0 +1,60 @@
+BEGIN;
+
+-- Adds the reviewer count to the backstops of 018: a PR has at most as many
+-- regular and lead reviewers as the primary team of its author asks for, two
+-- without a team. Shadow reviewers come on top. The PR row is locked, so
+-- concurrent changes of the same PR are counted one after another.
+-- Replacing a reviewer updates the row in place and keeps the count.
+CREATE OR REPLACE FUNCTION pr_reviewers_check() RETURNS trigger AS $$
+DECLARE
+    pr_status TEXT;
+    pr_author TEXT;
+    changed_pr TEXT;
+    max_reviewers INT;
+    assigned INT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_pr := OLD.pr_id;
+    ELSE
+        changed_pr := NEW.pr_id;
+    END IF;
+
+    SELECT status, author_id INTO pr_status, pr_author
+    FROM pull_requests WHERE pr_id = changed_pr
+    FOR UPDATE;
+
+    IF pr_status <> 'OPEN' THEN
+        RAISE EXCEPTION 'reviewers of % pull request % cannot change', lower(pr_status), changed_pr;
+    END IF;
+    IF TG_OP <> 'DELETE' AND NEW.reviewer_id = pr_author THEN
+        RAISE EXCEPTION 'author % cannot review pull request %', pr_author, changed_pr;
+    END IF;
+
+    IF TG_OP = 'INSERT' AND NEW.slot <> 'shadow' THEN
+        SELECT t.reviewers_count INTO max_reviewers
+        FROM team_memberships m
+        JOIN teams t ON t.org_id = m.org_id AND t.team_name = m.team_name
+        WHERE m.user_id = pr_author AND m.is_primary;
+
+        SELECT COUNT(*) INTO assigned
+        FROM pull_request_reviewers
+        WHERE pr_id = changed_pr AND slot <> 'shadow';
+
+        IF assigned >= COALESCE(max_reviewers, 2) THEN
+            RAISE EXCEPTION 'pull request % already has % reviewers', changed_pr, assigned;
+        END IF;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER trg_pr_reviewers_check ON pull_request_reviewers;
+CREATE TRIGGER trg_pr_reviewers_check
+    BEFORE INSERT OR UPDATE OF reviewer_id OR DELETE ON pull_request_reviewers
+    FOR EACH ROW EXECUTE FUNCTION pr_reviewers_check();
+
+COMMIT;
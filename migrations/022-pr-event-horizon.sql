@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Event ids come from a sequence, so transactions that commit out of order
+-- make a higher id visible before a lower one. Readers of the log only hand
+-- out events that are settled: xid_horizon is the next transaction id at the
+-- moment the event got its id, and once every transaction below it has ended
+-- no event with a lower id can still appear. The id is drawn after the
+-- writing transaction has its own id, which the argument relies on; writers
+-- run at READ COMMITTED so that the horizon is read after the id is drawn.
+ALTER TABLE pr_events ADD COLUMN xid_horizon xid8 NOT NULL DEFAULT '0';
+ALTER TABLE pr_events ALTER COLUMN xid_horizon DROP DEFAULT;
+ALTER TABLE pr_events ALTER COLUMN event_id DROP DEFAULT;
+
+CREATE FUNCTION pr_events_number() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_current_xact_id();
+    NEW.event_id := nextval('pr_events_event_id_seq');
+    NEW.xid_horizon := pg_snapshot_xmax(pg_current_snapshot());
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_pr_events_number
+    BEFORE INSERT ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION pr_events_number();
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- A request holds its in-flight idempotency key under a lease. Once the lease
+-- runs out, e.g. because the process handling the request crashed, the next
+-- request with the key claims it again. Only the holder of the lease may
+-- store a response for the key or release it.
+ALTER TABLE idempotency_keys
+    ADD COLUMN lease_id TEXT,
+    ADD COLUMN lease_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Keys claimed before leases existed are held for a minute from their claim.
+UPDATE idempotency_keys
+SET lease_expires_at = created_at + interval '1 minute'
+WHERE status_code IS NULL;
+
+COMMIT;
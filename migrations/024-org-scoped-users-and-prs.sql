@@ -0,0 +1,206 @@
+BEGIN;
+
+-- User and pull request ids are unique within an organization, like team
+-- names since 020. Every table that points at a user or a pull request
+-- carries the organization, so that its foreign keys can point at the
+-- composite keys and a row can never refer to another organization's data.
+ALTER TABLE pull_request_reviewers ADD COLUMN org_id TEXT REFERENCES organizations (org_id);
+ALTER TABLE pull_request_declines ADD COLUMN org_id TEXT REFERENCES organizations (org_id);
+ALTER TABLE pr_events ADD COLUMN org_id TEXT REFERENCES organizations (org_id);
+ALTER TABLE assignment_decisions ADD COLUMN org_id TEXT REFERENCES organizations (org_id);
+
+-- The backfill neither counts as a change of the pull requests nor as an
+-- edit of their history.
+ALTER TABLE pull_request_reviewers DISABLE TRIGGER trg_pr_reviewers_version;
+ALTER TABLE pull_request_declines DISABLE TRIGGER trg_pr_declines_version;
+ALTER TABLE pr_events DISABLE TRIGGER trg_pr_events_append_only;
+
+UPDATE pull_request_reviewers c SET org_id = pr.org_id FROM pull_requests pr WHERE pr.pr_id = c.pr_id;
+UPDATE pull_request_declines c SET org_id = pr.org_id FROM pull_requests pr WHERE pr.pr_id = c.pr_id;
+UPDATE pr_events c SET org_id = pr.org_id FROM pull_requests pr WHERE pr.pr_id = c.pr_id;
+UPDATE assignment_decisions c SET org_id = pr.org_id FROM pull_requests pr WHERE pr.pr_id = c.pr_id;
+
+ALTER TABLE pull_request_reviewers ENABLE TRIGGER trg_pr_reviewers_version;
+ALTER TABLE pull_request_declines ENABLE TRIGGER trg_pr_declines_version;
+ALTER TABLE pr_events ENABLE TRIGGER trg_pr_events_append_only;
+
+ALTER TABLE pull_request_reviewers ALTER COLUMN org_id SET NOT NULL;
+ALTER TABLE pull_request_declines ALTER COLUMN org_id SET NOT NULL;
+ALTER TABLE pr_events ALTER COLUMN org_id SET NOT NULL;
+ALTER TABLE assignment_decisions ALTER COLUMN org_id SET NOT NULL;
+
+ALTER TABLE pull_requests DROP CONSTRAINT fk_pr_author;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_prrev_pr;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_prrev_user;
+ALTER TABLE pull_request_declines DROP CONSTRAINT fk_prdecl_pr;
+ALTER TABLE pull_request_declines DROP CONSTRAINT fk_prdecl_user;
+ALTER TABLE pr_events DROP CONSTRAINT fk_prevents_pr;
+ALTER TABLE assignment_decisions DROP CONSTRAINT fk_decisions_pr;
+ALTER TABLE team_memberships DROP CONSTRAINT fk_memberships_user;
+ALTER TABLE api_tokens DROP CONSTRAINT fk_tokens_user;
+
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+ALTER TABLE users ADD PRIMARY KEY (org_id, user_id);
+DROP INDEX idx_users_org_id;
+
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (org_id, pr_id);
+
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (org_id, pr_id, reviewer_id);
+ALTER TABLE pull_request_declines DROP CONSTRAINT pull_request_declines_pkey;
+ALTER TABLE pull_request_declines ADD PRIMARY KEY (org_id, pr_id, reviewer_id);
+ALTER TABLE team_memberships DROP CONSTRAINT team_memberships_pkey;
+ALTER TABLE team_memberships ADD PRIMARY KEY (org_id, user_id, team_name);
+DROP INDEX ux_memberships_primary;
+CREATE UNIQUE INDEX ux_memberships_primary ON team_memberships(org_id, user_id) WHERE is_primary;
+
+ALTER TABLE pull_requests ADD CONSTRAINT fk_pr_author FOREIGN KEY (org_id, author_id)
+    REFERENCES users (org_id, user_id)
+    ON DELETE RESTRICT;
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT fk_prrev_pr FOREIGN KEY (org_id, pr_id)
+    REFERENCES pull_requests (org_id, pr_id)
+    ON DELETE CASCADE;
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT fk_prrev_user FOREIGN KEY (org_id, reviewer_id)
+    REFERENCES users (org_id, user_id)
+    ON DELETE RESTRICT;
+ALTER TABLE pull_request_declines ADD CONSTRAINT fk_prdecl_pr FOREIGN KEY (org_id, pr_id)
+    REFERENCES pull_requests (org_id, pr_id)
+    ON DELETE CASCADE;
+ALTER TABLE pull_request_declines ADD CONSTRAINT fk_prdecl_user FOREIGN KEY (org_id, reviewer_id)
+    REFERENCES users (org_id, user_id)
+    ON DELETE RESTRICT;
+ALTER TABLE pr_events ADD CONSTRAINT fk_prevents_pr FOREIGN KEY (org_id, pr_id)
+    REFERENCES pull_requests (org_id, pr_id)
+    ON DELETE RESTRICT;
+ALTER TABLE assignment_decisions ADD CONSTRAINT fk_decisions_pr FOREIGN KEY (org_id, pr_id)
+    REFERENCES pull_requests (org_id, pr_id)
+    ON DELETE CASCADE;
+ALTER TABLE team_memberships ADD CONSTRAINT fk_memberships_user FOREIGN KEY (org_id, user_id)
+    REFERENCES users (org_id, user_id)
+    ON DELETE CASCADE;
+-- Service tokens have no user_id; the key is then not checked.
+ALTER TABLE api_tokens ADD CONSTRAINT fk_tokens_user FOREIGN KEY (org_id, user_id)
+    REFERENCES users (org_id, user_id)
+    ON DELETE CASCADE;
+
+-- Lookups by user or pull request now start with the organization.
+DROP INDEX idx_pr_created_at_pr_id;
+DROP INDEX idx_pr_author_created;
+CREATE INDEX idx_pr_author_created ON pull_requests(org_id, author_id, created_at DESC, pr_id DESC);
+DROP INDEX idx_pr_status_created;
+CREATE INDEX idx_pr_status_created ON pull_requests(org_id, status, created_at DESC, pr_id DESC);
+DROP INDEX idx_pr_queued;
+CREATE INDEX idx_pr_queued ON pull_requests (org_id, created_at, pr_id) WHERE queued;
+
+DROP INDEX idx_prrev_pr_id;
+DROP INDEX idx_prrev_reviewer_pr;
+CREATE INDEX idx_prrev_reviewer_pr ON pull_request_reviewers(org_id, reviewer_id, pr_id);
+DROP INDEX idx_prrev_reviewer_pending;
+CREATE INDEX idx_prrev_reviewer_pending ON pull_request_reviewers(org_id, reviewer_id, pr_id)
+    WHERE approved_at IS NULL;
+DROP INDEX idx_prdecl_reviewer_id;
+CREATE INDEX idx_prdecl_reviewer_id ON pull_request_declines(org_id, reviewer_id);
+
+DROP INDEX idx_prevents_pr_id;
+CREATE INDEX idx_prevents_pr_id ON pr_events(org_id, pr_id, event_id);
+CREATE INDEX idx_prevents_org_event ON pr_events(org_id, event_id);
+DROP INDEX idx_decisions_pr_id;
+CREATE INDEX idx_decisions_pr_id ON assignment_decisions(org_id, pr_id, decision_id);
+
+-- The foreign keys keep every row in the organization of the rows it points
+-- at, so the policies only need to compare org_id.
+DROP POLICY org_isolation ON team_memberships;
+CREATE POLICY org_isolation ON team_memberships
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+DROP POLICY org_isolation ON pull_request_reviewers;
+CREATE POLICY org_isolation ON pull_request_reviewers
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+DROP POLICY org_isolation ON pull_request_declines;
+CREATE POLICY org_isolation ON pull_request_declines
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+DROP POLICY org_isolation ON pr_events;
+CREATE POLICY org_isolation ON pr_events
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+DROP POLICY org_isolation ON assignment_decisions;
+CREATE POLICY org_isolation ON assignment_decisions
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+-- The triggers of 017 and 021 find the pull request by its composite key.
+CREATE OR REPLACE FUNCTION pr_children_bump_version() RETURNS trigger AS $$
+DECLARE
+    changed_org TEXT;
+    changed_pr TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_org := OLD.org_id;
+        changed_pr := OLD.pr_id;
+    ELSE
+        changed_org := NEW.org_id;
+        changed_pr := NEW.pr_id;
+    END IF;
+    UPDATE pull_requests SET version = version + 1 WHERE org_id = changed_org AND pr_id = changed_pr;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION pr_reviewers_check() RETURNS trigger AS $$
+DECLARE
+    pr_status TEXT;
+    pr_author TEXT;
+    changed_org TEXT;
+    changed_pr TEXT;
+    max_reviewers INT;
+    assigned INT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_org := OLD.org_id;
+        changed_pr := OLD.pr_id;
+    ELSE
+        changed_org := NEW.org_id;
+        changed_pr := NEW.pr_id;
+    END IF;
+
+    SELECT status, author_id INTO pr_status, pr_author
+    FROM pull_requests WHERE org_id = changed_org AND pr_id = changed_pr
+    FOR UPDATE;
+
+    IF pr_status <> 'OPEN' THEN
+        RAISE EXCEPTION 'reviewers of % pull request % cannot change', lower(pr_status), changed_pr;
+    END IF;
+    IF TG_OP <> 'DELETE' AND NEW.reviewer_id = pr_author THEN
+        RAISE EXCEPTION 'author % cannot review pull request %', pr_author, changed_pr;
+    END IF;
+
+    IF TG_OP = 'INSERT' AND NEW.slot <> 'shadow' THEN
+        SELECT t.reviewers_count INTO max_reviewers
+        FROM team_memberships m
+        JOIN teams t ON t.org_id = m.org_id AND t.team_name = m.team_name
+        WHERE m.org_id = changed_org AND m.user_id = pr_author AND m.is_primary;
+
+        SELECT COUNT(*) INTO assigned
+        FROM pull_request_reviewers
+        WHERE org_id = changed_org AND pr_id = changed_pr AND slot <> 'shadow';
+
+        IF assigned >= COALESCE(max_reviewers, 2) THEN
+            RAISE EXCEPTION 'pull request % already has % reviewers', changed_pr, assigned;
+        END IF;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;